@@ -1,7 +1,52 @@
 package hubspot
 
-// AssociationContactToCompany is the value for the "AssociationType" when associating contact and companies
-const AssociationContactToCompany = "contact_to_company"
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+)
+
+// associationsAPIVersion is the version of HubSpot's associations API with labels
+const associationsAPIVersion = "v4"
+
+// Values for the "AssociationType" when associating two objects
+const (
+	// AssociationContactToCompany is the value for the "AssociationType" when associating contact and companies
+	AssociationContactToCompany = "contact_to_company"
+	// AssociationDealToContact is the value for the "AssociationType" when associating deals and contacts
+	AssociationDealToContact = "deal_to_contact"
+	// AssociationDealToCompany is the value for the "AssociationType" when associating deals and companies
+	AssociationDealToCompany = "deal_to_company"
+	// AssociationTicketToContact is the value for the "AssociationType" when associating tickets and contacts
+	AssociationTicketToContact = "ticket_to_contact"
+	// AssociationTicketToCompany is the value for the "AssociationType" when associating tickets and companies
+	AssociationTicketToCompany = "ticket_to_company"
+)
+
+// Association categories of the v4 associations API
+const (
+	AssociationCategoryHubSpotDefined    = "HUBSPOT_DEFINED"
+	AssociationCategoryUserDefined       = "USER_DEFINED"
+	AssociationCategoryIntegratorDefined = "INTEGRATOR_DEFINED"
+)
+
+// Association type IDs of the HUBSPOT_DEFINED category, labels have their own type IDs
+const (
+	AssociationTypeIDContactToCompanyPrimary = 1
+	AssociationTypeIDDealToContact           = 3
+	AssociationTypeIDContactToDeal           = 4
+	AssociationTypeIDDealToCompanyPrimary    = 5
+	AssociationTypeIDTicketToContact         = 16
+	AssociationTypeIDContactToCompany        = 279
+	AssociationTypeIDCompanyToContact        = 280
+	AssociationTypeIDTicketToCompany         = 339
+	AssociationTypeIDDealToCompany           = 341
+)
 
 type (
 	// AssociationInput handles an association from one type of object to another
@@ -11,9 +56,28 @@ type (
 
 	// Association handles the two items to be associated
 	Association struct {
-		AssociationType string        `json:"type"`
+		// AssociationType is the v3 association type, e.g. AssociationContactToCompany
+		AssociationType string        `json:"type,omitempty"`
 		From            AssociationID `json:"from"`
 		To              AssociationID `json:"to"`
+		// Types are the v4 association types, e.g. labels, the association is created with
+		Types []AssociationSpec `json:"types,omitempty"`
+		// Labels are the labels of an association created with the v4 API
+		Labels []string `json:"labels,omitempty"`
+	}
+
+	// AssociationSpec handles a v4 association type, a category and type ID pair
+	AssociationSpec struct {
+		Category string `json:"associationCategory"`
+		TypeID   int    `json:"associationTypeId"`
+	}
+
+	// AssociationType handles a v4 association type of an existing association, or an association
+	// label definition between two object types. Label is empty for unlabeled associations.
+	AssociationType struct {
+		Category string `json:"category"`
+		TypeID   int    `json:"typeId"`
+		Label    string `json:"label,omitempty"`
 	}
 
 	// AssociationID handles the IDs to be associated
@@ -21,28 +85,470 @@ type (
 		ID string `json:"id"`
 	}
 
-	// AssociationResults handles the results from a successful call to the HubSpot Association API
+	// AssociationResults handles the results from a successful call to the HubSpot Association API,
+	// a multi-status response of the v4 API lists the associations HubSpot could not create in Errors
 	AssociationResults struct {
-		Status      string        `json:"string"`
+		Status      string        `json:"status"`
 		StartedAt   string        `json:"startedAt"`
 		CompletedAt string        `json:"completedAt"`
 		Results     []Association `json:"results"`
+		NumErrors   int           `json:"numErrors,omitempty"`
+		Errors      []APIError    `json:"errors,omitempty"`
+	}
+
+	// AssociatedRecord handles an object associated with a record and the types of the association
+	AssociatedRecord struct {
+		ToObjectID       string
+		AssociationTypes []AssociationType
+	}
+
+	// AssociationPage handles a page of the objects associated with a record
+	AssociationPage struct {
+		Results []AssociatedRecord
+		Paging  *Paging
+	}
+
+	// RecordAssociations handles the objects associated with a single record of a batch read
+	RecordAssociations struct {
+		From AssociationID
+		To   []AssociatedRecord
+		// Paging is set when the record has more associations than returned
+		Paging *Paging
+	}
+
+	// AssociationBatchReadResults handles the results of a batch read, a multi-status response
+	// lists the records HubSpot could not read in Errors
+	AssociationBatchReadResults struct {
+		Status      string
+		Results     []RecordAssociations
+		NumErrors   int
+		Errors      []APIError
+		StartedAt   string
+		CompletedAt string
+	}
+
+	// AssociationListOptions handles the optional query parameters when listing associations
+	AssociationListOptions struct {
+		// Limit is the page size, HubSpot defaults to 500
+		Limit int
+		// After is the paging cursor returned with the previous page
+		After string
+	}
+
+	// AssociationLabelInput handles the body representation of a new association label, a paired
+	// label has a different label for the inverse direction, e.g. "Manager" and "Employee"
+	AssociationLabelInput struct {
+		// Name is the internal name of the label
+		Name         string `json:"name"`
+		Label        string `json:"label"`
+		InverseLabel string `json:"inverseLabel,omitempty"`
+	}
+
+	// AssociationLabelUpdateInput handles the body representation of an association label update
+	AssociationLabelUpdateInput struct {
+		TypeID       int    `json:"associationTypeId"`
+		Label        string `json:"label"`
+		InverseLabel string `json:"inverseLabel,omitempty"`
+	}
+
+	// associationTypeResults handles the association label definitions between two object types
+	associationTypeResults struct {
+		Results []AssociationType `json:"results"`
+	}
+
+	// labeledAssociation handles an association created with the v4 API, IDs are numbers
+	labeledAssociation struct {
+		FromObjectID json.Number `json:"fromObjectId"`
+		ToObjectID   json.Number `json:"toObjectId"`
+		Labels       []string    `json:"labels"`
+	}
+
+	// labeledAssociationResults handles the results of a v4 batch create
+	labeledAssociationResults struct {
+		Status      string               `json:"status"`
+		StartedAt   string               `json:"startedAt"`
+		CompletedAt string               `json:"completedAt"`
+		Results     []labeledAssociation `json:"results"`
+		NumErrors   int                  `json:"numErrors"`
+		Errors      []APIError           `json:"errors"`
+	}
+
+	// defaultAssociationResults handles the results of creating a default association
+	defaultAssociationResults struct {
+		Results []struct {
+			From            AssociationID   `json:"from"`
+			To              AssociationID   `json:"to"`
+			AssociationSpec AssociationSpec `json:"associationSpec"`
+		} `json:"results"`
+	}
+
+	// associatedRecord handles an associated object of the v4 API, IDs are numbers
+	associatedRecord struct {
+		ToObjectID       json.Number       `json:"toObjectId"`
+		AssociationTypes []AssociationType `json:"associationTypes"`
+	}
+
+	// associationPage handles a page of associated objects of the v4 API
+	associationPage struct {
+		Results []associatedRecord `json:"results"`
+		Paging  *Paging            `json:"paging"`
+	}
+
+	// associationBatchReadResults handles the results of a v4 batch read
+	associationBatchReadResults struct {
+		Status  string `json:"status"`
+		Results []struct {
+			From   AssociationID      `json:"from"`
+			To     []associatedRecord `json:"to"`
+			Paging *Paging            `json:"paging"`
+		} `json:"results"`
+		NumErrors   int        `json:"numErrors"`
+		Errors      []APIError `json:"errors"`
+		StartedAt   string     `json:"startedAt"`
+		CompletedAt string     `json:"completedAt"`
+	}
+
+	// associationArchiveInput handles the associations of a single record to archive
+	associationArchiveInput struct {
+		From AssociationID   `json:"from"`
+		To   []AssociationID `json:"to"`
 	}
 )
 
 // NewSingleContactToCompanyAssociationInput can be used to connect a company to a contact
 func NewSingleContactToCompanyAssociationInput(contactID string, companyID string) *AssociationInput {
+	return newSingleAssociationInput(AssociationContactToCompany, contactID, companyID)
+}
+
+// NewSingleDealToContactAssociationInput can be used to connect a contact to a deal
+func NewSingleDealToContactAssociationInput(dealID string, contactID string) *AssociationInput {
+	return newSingleAssociationInput(AssociationDealToContact, dealID, contactID)
+}
+
+// NewSingleDealToCompanyAssociationInput can be used to connect a company to a deal
+func NewSingleDealToCompanyAssociationInput(dealID string, companyID string) *AssociationInput {
+	return newSingleAssociationInput(AssociationDealToCompany, dealID, companyID)
+}
+
+// NewSingleTicketToContactAssociationInput can be used to connect a contact to a ticket
+func NewSingleTicketToContactAssociationInput(ticketID string, contactID string) *AssociationInput {
+	return newSingleAssociationInput(AssociationTicketToContact, ticketID, contactID)
+}
+
+// NewSingleTicketToCompanyAssociationInput can be used to connect a company to a ticket
+func NewSingleTicketToCompanyAssociationInput(ticketID string, companyID string) *AssociationInput {
+	return newSingleAssociationInput(AssociationTicketToCompany, ticketID, companyID)
+}
+
+// newSingleAssociationInput creates an AssociationInput relating a single pair of objects
+func newSingleAssociationInput(associationType string, fromID string, toID string) *AssociationInput {
 	return &AssociationInput{
 		Inputs: []Association{
-			Association{
-				AssociationType: AssociationContactToCompany,
+			{
+				AssociationType: associationType,
 				From: AssociationID{
-					ID: contactID,
+					ID: fromID,
 				},
 				To: AssociationID{
-					ID: companyID,
+					ID: toID,
 				},
 			},
 		},
 	}
 }
+
+// NewLabeledAssociationInput creates an AssociationInput relating a single pair of objects with the
+// v4 association types, e.g. labels, for AssociationsService.BatchCreate
+func NewLabeledAssociationInput(fromID string, toID string, types ...AssociationSpec) *AssociationInput {
+	return &AssociationInput{
+		Inputs: []Association{
+			{
+				From:  AssociationID{ID: fromID},
+				To:    AssociationID{ID: toID},
+				Types: types,
+			},
+		},
+	}
+}
+
+// AssociationsService handles the v4 associations API between two object types
+type AssociationsService struct {
+	client         *Client
+	fromObjectType string
+	toObjectType   string
+}
+
+// Associations returns the v4 associations API from one object type to another,
+// e.g. ObjectTypeContacts to ObjectTypeCompanies
+func (c *Client) Associations(fromObjectType string, toObjectType string) *AssociationsService {
+	return &AssociationsService{
+		client:         c,
+		fromObjectType: fromObjectType,
+		toObjectType:   toObjectType,
+	}
+}
+
+// Create associates two records with the association types, e.g. labels,
+// or with the default association type when none are given
+func (s *AssociationsService) Create(
+	ctx context.Context,
+	fromID string,
+	toID string,
+	types ...AssociationSpec) (*Association, error) {
+
+	if fromID == "" || toID == "" {
+		return nil, errors.New("association requires a from and a to id")
+	}
+
+	if len(types) == 0 {
+		apiURL := s.recordURL(fromID, "default/"+s.toObjectType+"/"+url.PathEscape(toID), nil)
+
+		var results defaultAssociationResults
+		if err := s.client.do(ctx, http.MethodPut, apiURL, nil, http.StatusOK, &results); err != nil {
+			return nil, err
+		}
+
+		association := &Association{From: AssociationID{ID: fromID}, To: AssociationID{ID: toID}}
+		for _, result := range results.Results {
+			association.Types = append(association.Types, result.AssociationSpec)
+		}
+		return association, nil
+	}
+
+	apiURL := s.recordURL(fromID, s.toObjectType+"/"+url.PathEscape(toID), nil)
+
+	// HubSpot answers with 201 Created, 200 OK is accepted as well
+	var result labeledAssociation
+	if err := s.client.do(ctx, http.MethodPut, apiURL, types, http.StatusCreated, &result, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	return &Association{
+		From:   AssociationID{ID: fromID},
+		To:     AssociationID{ID: toID},
+		Types:  types,
+		Labels: result.Labels,
+	}, nil
+}
+
+// BatchCreate associates pairs of records with the association types of each input
+func (s *AssociationsService) BatchCreate(ctx context.Context, input *AssociationInput) (*AssociationResults, error) {
+	var results labeledAssociationResults
+	if err := s.client.doBatch(ctx, s.batchURL("create"), input, http.StatusCreated, &results); err != nil {
+		return nil, err
+	}
+
+	associationResults := &AssociationResults{
+		Status:      results.Status,
+		StartedAt:   results.StartedAt,
+		CompletedAt: results.CompletedAt,
+		NumErrors:   results.NumErrors,
+		Errors:      results.Errors,
+	}
+	for _, result := range results.Results {
+		associationResults.Results = append(associationResults.Results, Association{
+			From:   AssociationID{ID: result.FromObjectID.String()},
+			To:     AssociationID{ID: result.ToObjectID.String()},
+			Labels: result.Labels,
+		})
+	}
+	return associationResults, nil
+}
+
+// List gets a page of the records associated with a record, pass Paging.Next.After of a page
+// as opts.After for the next one
+func (s *AssociationsService) List(ctx context.Context, fromID string, opts *AssociationListOptions) (*AssociationPage, error) {
+	if fromID == "" {
+		return nil, errors.New("association requires a from id")
+	}
+
+	apiURL := s.recordURL(fromID, s.toObjectType, opts.query())
+
+	var page associationPage
+	if err := s.client.do(ctx, http.MethodGet, apiURL, nil, http.StatusOK, &page); err != nil {
+		return nil, err
+	}
+
+	return &AssociationPage{
+		Results: associatedRecords(page.Results),
+		Paging:  page.Paging,
+	}, nil
+}
+
+// BatchRead gets the records associated with each of up to BatchLimit records
+func (s *AssociationsService) BatchRead(ctx context.Context, fromIDs []string) (*AssociationBatchReadResults, error) {
+	if err := checkBatchSize(len(fromIDs)); err != nil {
+		return nil, err
+	}
+
+	var results associationBatchReadResults
+	if err := s.client.doBatch(ctx, s.batchURL("read"), batchRequest{Inputs: batchObjectIDs(fromIDs)}, http.StatusOK, &results); err != nil {
+		return nil, err
+	}
+
+	readResults := &AssociationBatchReadResults{
+		Status:      results.Status,
+		NumErrors:   results.NumErrors,
+		Errors:      results.Errors,
+		StartedAt:   results.StartedAt,
+		CompletedAt: results.CompletedAt,
+	}
+	for _, result := range results.Results {
+		readResults.Results = append(readResults.Results, RecordAssociations{
+			From:   result.From,
+			To:     associatedRecords(result.To),
+			Paging: result.Paging,
+		})
+	}
+	return readResults, nil
+}
+
+// BatchArchive removes every association between each pair of records of the input, which
+// may name up to BatchLimit from records
+func (s *AssociationsService) BatchArchive(ctx context.Context, input *AssociationInput) error {
+	var inputs []associationArchiveInput
+	indexes := map[string]int{}
+	if input != nil {
+		for _, association := range input.Inputs {
+			i, ok := indexes[association.From.ID]
+			if !ok {
+				i = len(inputs)
+				indexes[association.From.ID] = i
+				inputs = append(inputs, associationArchiveInput{From: association.From})
+			}
+			inputs[i].To = append(inputs[i].To, association.To)
+		}
+	}
+
+	if err := checkBatchSize(len(inputs)); err != nil {
+		return err
+	}
+
+	return s.client.do(ctx, http.MethodPost, s.batchURL("archive"), batchRequest{Inputs: inputs}, http.StatusNoContent, nil)
+}
+
+// BatchArchiveLabels removes the association types, e.g. labels, of each input from the association
+// between its pair of records, the records stay associated
+func (s *AssociationsService) BatchArchiveLabels(ctx context.Context, input *AssociationInput) error {
+	return s.client.do(ctx, http.MethodPost, s.batchURL("labels/archive"), input, http.StatusNoContent, nil)
+}
+
+// Archive removes every association between two records
+func (s *AssociationsService) Archive(ctx context.Context, fromID string, toID string) error {
+	if fromID == "" || toID == "" {
+		return errors.New("association requires a from and a to id")
+	}
+	apiURL := s.recordURL(fromID, s.toObjectType+"/"+url.PathEscape(toID), nil)
+	return s.client.do(ctx, http.MethodDelete, apiURL, nil, http.StatusNoContent, nil)
+}
+
+// ListLabels gets the association types between the two object types, including the
+// unlabeled HubSpot defined ones
+func (s *AssociationsService) ListLabels(ctx context.Context) ([]AssociationType, error) {
+	var results associationTypeResults
+	if err := s.client.do(ctx, http.MethodGet, s.labelsURL(""), nil, http.StatusOK, &results); err != nil {
+		return nil, err
+	}
+
+	return results.Results, nil
+}
+
+// ReadLabel gets the association type with the label between the two object types,
+// a missing label returns ErrNotFound
+func (s *AssociationsService) ReadLabel(ctx context.Context, label string) (*AssociationType, error) {
+	if label == "" {
+		return nil, errors.New("association label requires a label")
+	}
+
+	types, err := s.ListLabels(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range types {
+		if types[i].Label == label {
+			return &types[i], nil
+		}
+	}
+	return nil, fmt.Errorf("association label %q from %s to %s: %w", label, s.fromObjectType, s.toObjectType, ErrNotFound)
+}
+
+// CreateLabel creates a new association label between the two object types and returns its
+// association types. A paired label returns one for each direction in no documented order,
+// so they are reordered to start with the from to type, labelled input.Label, followed by
+// the inverse type, labelled input.InverseLabel.
+func (s *AssociationsService) CreateLabel(ctx context.Context, input *AssociationLabelInput) ([]AssociationType, error) {
+	var results associationTypeResults
+	if err := s.client.do(ctx, http.MethodPost, s.labelsURL(""), input, http.StatusOK, &results); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(results.Results, func(i, j int) bool {
+		return results.Results[i].Label == input.Label && results.Results[j].Label != input.Label
+	})
+	return results.Results, nil
+}
+
+// UpdateLabel renames an association label between the two object types
+func (s *AssociationsService) UpdateLabel(ctx context.Context, input *AssociationLabelUpdateInput) error {
+	return s.client.do(ctx, http.MethodPut, s.labelsURL(""), input, http.StatusNoContent, nil)
+}
+
+// DeleteLabel deletes an association label between the two object types by its type ID
+func (s *AssociationsService) DeleteLabel(ctx context.Context, typeID int) error {
+	return s.client.do(ctx, http.MethodDelete, s.labelsURL(strconv.Itoa(typeID)), nil, http.StatusNoContent, nil)
+}
+
+// labelsURL returns the URL of the association labels between the two object types,
+// or of a single label when typeID is set
+func (s *AssociationsService) labelsURL(typeID string) string {
+	path := fmt.Sprintf("/crm/%s/associations/%s/%s/labels", associationsAPIVersion, s.fromObjectType, s.toObjectType)
+	if typeID != "" {
+		path += "/" + typeID
+	}
+	return s.client.buildURL(path, nil)
+}
+
+// Spec returns the category and type ID pair to create an association of the type with
+func (t AssociationType) Spec() AssociationSpec {
+	return AssociationSpec{Category: t.Category, TypeID: t.TypeID}
+}
+
+// recordURL returns the URL of the associations of a single record
+func (s *AssociationsService) recordURL(fromID string, path string, query url.Values) string {
+	return s.client.buildURL(fmt.Sprintf("/crm/%s/objects/%s/%s/associations/%s",
+		associationsAPIVersion, s.fromObjectType, url.PathEscape(fromID), path), query)
+}
+
+// batchURL returns the URL of a batch operation between the two object types
+func (s *AssociationsService) batchURL(operation string) string {
+	return s.client.buildURL(fmt.Sprintf("/crm/%s/associations/%s/%s/batch/%s",
+		associationsAPIVersion, s.fromObjectType, s.toObjectType, operation), nil)
+}
+
+// query returns the query parameters of the association list options
+func (o *AssociationListOptions) query() url.Values {
+	query := url.Values{}
+	if o == nil {
+		return query
+	}
+	if o.Limit > 0 {
+		query.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.After != "" {
+		query.Set("after", o.After)
+	}
+	return query
+}
+
+// associatedRecords converts associated objects of the v4 API
+func associatedRecords(records []associatedRecord) []AssociatedRecord {
+	converted := make([]AssociatedRecord, len(records))
+	for i, record := range records {
+		converted[i] = AssociatedRecord{
+			ToObjectID:       record.ToObjectID.String(),
+			AssociationTypes: record.AssociationTypes,
+		}
+	}
+	return converted
+}
@@ -0,0 +1,287 @@
+package hubspot_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	hubSpot "github.com/teamexos/hubspot-api-go/hubspot"
+)
+
+func TestAssociationsCreate(t *testing.T) {
+	c := hubSpot.NewClient("fake-api-key")
+
+	var gotRequest *http.Request
+	var gotBody []byte
+	status := http.StatusCreated
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			gotRequest = req
+			gotBody, _ = ioutil.ReadAll(req.Body)
+			return newMockResponse(status, `{
+				"fromObjectTypeId": "0-1",
+				"fromObjectId": 3051,
+				"toObjectTypeId": "0-2",
+				"toObjectId": 4705054985,
+				"labels": ["Billing Contact"]
+			}`), nil
+		},
+	}
+
+	associations := c.Associations(hubSpot.ObjectTypeContacts, hubSpot.ObjectTypeCompanies)
+	spec := hubSpot.AssociationSpec{Category: hubSpot.AssociationCategoryUserDefined, TypeID: 36}
+
+	association, err := associations.Create(context.Background(), "3051", "4705054985", spec)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPut, gotRequest.Method)
+	assert.Equal(t, "/crm/v4/objects/contacts/3051/associations/companies/4705054985", gotRequest.URL.Path)
+	assert.JSONEq(t, `[{"associationCategory": "USER_DEFINED", "associationTypeId": 36}]`, string(gotBody))
+	assert.Equal(t, []string{"Billing Contact"}, association.Labels)
+	assert.Equal(t, "4705054985", association.To.ID)
+
+	status = http.StatusOK
+	association, err = associations.Create(context.Background(), "3051", "4705054985", spec)
+	assert.NoError(t, err, "expected 200 OK to be accepted as well")
+	assert.Equal(t, []string{"Billing Contact"}, association.Labels)
+
+	status = http.StatusMultiStatus
+	_, err = associations.Create(context.Background(), "3051", "4705054985", spec)
+	var apiErr *hubSpot.APIError
+	if assert.True(t, errors.As(err, &apiErr), "expected other statuses to be an API error") {
+		assert.Equal(t, http.StatusMultiStatus, apiErr.StatusCode)
+	}
+}
+
+func TestAssociationsBatchCreate(t *testing.T) {
+	c := hubSpot.NewClient("fake-api-key")
+
+	var gotRequest *http.Request
+	var gotBody map[string]interface{}
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			gotRequest = req
+			body, _ := ioutil.ReadAll(req.Body)
+			assert.NoError(t, json.Unmarshal(body, &gotBody))
+			return newMockResponse(http.StatusMultiStatus, `{
+				"status": "COMPLETE",
+				"results": [
+					{"fromObjectTypeId": "0-1", "fromObjectId": 3051, "toObjectTypeId": "0-2", "toObjectId": 4705054985,
+						"labels": ["Primary Coach"]}
+				],
+				"numErrors": 1,
+				"errors": [
+					{"status": "error", "category": "OBJECT_NOT_FOUND", "message": "No company with ID 1",
+						"context": {"ids": ["1"]}}
+				],
+				"startedAt": "2021-01-12T15:47:54.554Z",
+				"completedAt": "2021-01-12T15:47:54.870Z"
+			}`), nil
+		},
+	}
+
+	primaryCoach := hubSpot.AssociationSpec{Category: hubSpot.AssociationCategoryUserDefined, TypeID: 38}
+	input := hubSpot.NewLabeledAssociationInput("3051", "4705054985", primaryCoach)
+	input.Inputs = append(input.Inputs, hubSpot.NewLabeledAssociationInput("3051", "1", primaryCoach).Inputs...)
+
+	results, err := c.Associations(hubSpot.ObjectTypeContacts, hubSpot.ObjectTypeCompanies).BatchCreate(context.Background(), input)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "/crm/v4/associations/contacts/companies/batch/create", gotRequest.URL.Path)
+	assert.Equal(t, map[string]interface{}{
+		"from":  map[string]interface{}{"id": "3051"},
+		"to":    map[string]interface{}{"id": "4705054985"},
+		"types": []interface{}{map[string]interface{}{"associationCategory": "USER_DEFINED", "associationTypeId": float64(38)}},
+	}, gotBody["inputs"].([]interface{})[0], "expected no v3 association type to be sent")
+	assert.Equal(t, []hubSpot.Association{{
+		From:   hubSpot.AssociationID{ID: "3051"},
+		To:     hubSpot.AssociationID{ID: "4705054985"},
+		Labels: []string{"Primary Coach"},
+	}}, results.Results)
+	assert.Equal(t, 1, results.NumErrors)
+	assert.Equal(t, []string{"1"}, results.Errors[0].Context["ids"])
+}
+
+func TestAssociationsList(t *testing.T) {
+	c := hubSpot.NewClient("fake-api-key")
+
+	var gotRequest *http.Request
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			gotRequest = req
+			return newMockResponse(http.StatusOK, `{
+				"results": [
+					{"toObjectId": 4705054985, "associationTypes": [
+						{"category": "HUBSPOT_DEFINED", "typeId": 279, "label": null},
+						{"category": "USER_DEFINED", "typeId": 36, "label": "Billing Contact"}
+					]}
+				],
+				"paging": {"next": {"after": "MTA%3D", "link": ""}}
+			}`), nil
+		},
+	}
+
+	page, err := c.Associations(hubSpot.ObjectTypeContacts, hubSpot.ObjectTypeCompanies).List(
+		context.Background(), "3051", &hubSpot.AssociationListOptions{Limit: 10, After: "MA%3D"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "/crm/v4/objects/contacts/3051/associations/companies", gotRequest.URL.Path)
+	assert.Equal(t, "10", gotRequest.URL.Query().Get("limit"))
+	assert.Equal(t, "MA%3D", gotRequest.URL.Query().Get("after"))
+	assert.Equal(t, []hubSpot.AssociatedRecord{{
+		ToObjectID: "4705054985",
+		AssociationTypes: []hubSpot.AssociationType{
+			{Category: hubSpot.AssociationCategoryHubSpotDefined, TypeID: hubSpot.AssociationTypeIDContactToCompany},
+			{Category: hubSpot.AssociationCategoryUserDefined, TypeID: 36, Label: "Billing Contact"},
+		},
+	}}, page.Results)
+	assert.Equal(t, "MTA%3D", page.Paging.NextAfter())
+}
+
+func TestAssociationsBatchReadAndArchive(t *testing.T) {
+	c := hubSpot.NewClient("fake-api-key")
+
+	var gotRequests []string
+	var gotBodies []string
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			gotRequests = append(gotRequests, req.URL.Path)
+			body, _ := ioutil.ReadAll(req.Body)
+			gotBodies = append(gotBodies, string(body))
+			if req.URL.Path == "/crm/v4/associations/contacts/companies/batch/read" {
+				return newMockResponse(http.StatusOK, `{
+					"status": "COMPLETE",
+					"results": [
+						{"from": {"id": "3051"}, "to": [
+							{"toObjectId": 4705054985, "associationTypes": [{"category": "HUBSPOT_DEFINED", "typeId": 1, "label": "Primary"}]}
+						]}
+					]
+				}`), nil
+			}
+			return newMockResponse(http.StatusNoContent, ``), nil
+		},
+	}
+	associations := c.Associations(hubSpot.ObjectTypeContacts, hubSpot.ObjectTypeCompanies)
+
+	results, err := associations.BatchRead(context.Background(), []string{"3051"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "3051", results.Results[0].From.ID)
+	assert.Equal(t, "4705054985", results.Results[0].To[0].ToObjectID)
+	assert.Equal(t, "Primary", results.Results[0].To[0].AssociationTypes[0].Label)
+
+	input := hubSpot.NewLabeledAssociationInput("3051", "4705054985")
+	input.Inputs = append(input.Inputs, hubSpot.Association{
+		From: hubSpot.AssociationID{ID: "3051"},
+		To:   hubSpot.AssociationID{ID: "4705054986"},
+	})
+	err = associations.BatchArchive(context.Background(), input)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "/crm/v4/associations/contacts/companies/batch/archive", gotRequests[1])
+	assert.JSONEq(t, `{"inputs": [{"from": {"id": "3051"}, "to": [{"id": "4705054985"}, {"id": "4705054986"}]}]}`, gotBodies[1],
+		"expected the associations to be grouped by record")
+}
+
+func TestAssociationsBatchSize(t *testing.T) {
+	c := hubSpot.NewClient("fake-api-key")
+
+	calls := 0
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			return newMockResponse(http.StatusNoContent, ``), nil
+		},
+	}
+	associations := c.Associations(hubSpot.ObjectTypeContacts, hubSpot.ObjectTypeCompanies)
+
+	_, err := associations.BatchRead(context.Background(), nil)
+	assert.EqualError(t, err, "batch requires between 1 and 100 inputs, got 0")
+
+	_, err = associations.BatchRead(context.Background(), make([]string, hubSpot.BatchLimit+1))
+	assert.EqualError(t, err, "batch requires between 1 and 100 inputs, got 101")
+
+	err = associations.BatchArchive(context.Background(), nil)
+	assert.EqualError(t, err, "batch requires between 1 and 100 inputs, got 0")
+
+	input := &hubSpot.AssociationInput{}
+	for i := 0; i <= hubSpot.BatchLimit; i++ {
+		input.Inputs = append(input.Inputs, hubSpot.Association{
+			From: hubSpot.AssociationID{ID: strconv.Itoa(i)},
+			To:   hubSpot.AssociationID{ID: "4705054985"},
+		})
+	}
+	err = associations.BatchArchive(context.Background(), input)
+	assert.EqualError(t, err, "batch requires between 1 and 100 inputs, got 101")
+
+	assert.Equal(t, 0, calls, "expected no request to be sent")
+}
+
+func TestAssociationLabels(t *testing.T) {
+	c := hubSpot.NewClient("fake-api-key")
+
+	var gotRequests []string
+	var gotBodies []string
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			gotRequests = append(gotRequests, req.Method+" "+req.URL.Path)
+			body, _ := ioutil.ReadAll(req.Body)
+			gotBodies = append(gotBodies, string(body))
+			switch req.Method {
+			case http.MethodGet:
+				return newMockResponse(http.StatusOK, `{"results": [
+					{"category": "HUBSPOT_DEFINED", "typeId": 279, "label": null},
+					{"category": "USER_DEFINED", "typeId": 36, "label": "Billing Contact"}
+				]}`), nil
+			case http.MethodPost:
+				return newMockResponse(http.StatusOK, `{"results": [
+					{"category": "USER_DEFINED", "typeId": 39, "label": "Coached Company"},
+					{"category": "USER_DEFINED", "typeId": 38, "label": "Primary Coach"}
+				]}`), nil
+			}
+			return newMockResponse(http.StatusNoContent, ``), nil
+		},
+	}
+	associations := c.Associations(hubSpot.ObjectTypeContacts, hubSpot.ObjectTypeCompanies)
+
+	created, err := associations.CreateLabel(context.Background(), &hubSpot.AssociationLabelInput{
+		Name:         "primary_coach",
+		Label:        "Primary Coach",
+		InverseLabel: "Coached Company",
+	})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"name": "primary_coach", "label": "Primary Coach", "inverseLabel": "Coached Company"}`, gotBodies[0])
+	assert.Equal(t, hubSpot.AssociationSpec{Category: hubSpot.AssociationCategoryUserDefined, TypeID: 38}, created[0].Spec(),
+		"expected the from to type first, converting to an association input type")
+	assert.Equal(t, "Coached Company", created[1].Label, "expected the inverse type last")
+
+	billingContact, err := associations.ReadLabel(context.Background(), "Billing Contact")
+	assert.NoError(t, err)
+	assert.Equal(t, 36, billingContact.TypeID)
+
+	_, err = associations.ReadLabel(context.Background(), "Emergency Contact")
+	assert.True(t, errors.Is(err, hubSpot.ErrNotFound), "expected a missing label to be not found")
+
+	_, err = associations.ReadLabel(context.Background(), "")
+	assert.EqualError(t, err, "association label requires a label")
+
+	err = associations.UpdateLabel(context.Background(), &hubSpot.AssociationLabelUpdateInput{TypeID: 36, Label: "Billing"})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"associationTypeId": 36, "label": "Billing"}`, gotBodies[3])
+
+	err = associations.DeleteLabel(context.Background(), 36)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"POST /crm/v4/associations/contacts/companies/labels",
+		"GET /crm/v4/associations/contacts/companies/labels",
+		"GET /crm/v4/associations/contacts/companies/labels",
+		"PUT /crm/v4/associations/contacts/companies/labels",
+		"DELETE /crm/v4/associations/contacts/companies/labels/36",
+	}, gotRequests)
+}
@@ -0,0 +1,279 @@
+package hubspot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultOAuthTokenURL is the HubSpot endpoint used to refresh OAuth 2.0 access tokens
+const DefaultOAuthTokenURL = "https://api.hubapi.com/oauth/v1/token"
+
+// oAuthExpiryMargin refreshes OAuth 2.0 tokens slightly before HubSpot expires them
+// so in-flight requests do not race the expiry
+const oAuthExpiryMargin = time.Minute
+
+// ErrNoOAuthToken is returned when the TokenStore has no OAuth 2.0 token to use
+var ErrNoOAuthToken = errors.New("no OAuth token available in token store")
+
+// Authenticator applies credentials to an outgoing HubSpot API request
+type Authenticator interface {
+	Authenticate(req *http.Request) error
+}
+
+// PrivateAppAuthenticator authenticates requests with a private app access token
+// sent as an Authorization: Bearer header
+type PrivateAppAuthenticator struct {
+	AccessToken string
+}
+
+// NewPrivateAppAuthenticator creates a new PrivateAppAuthenticator for the given access token
+func NewPrivateAppAuthenticator(accessToken string) *PrivateAppAuthenticator {
+	return &PrivateAppAuthenticator{
+		AccessToken: accessToken,
+	}
+}
+
+// Authenticate adds the private app access token to the request
+func (a *PrivateAppAuthenticator) Authenticate(req *http.Request) error {
+	if a.AccessToken == "" {
+		return errors.New("private app access token is empty")
+	}
+	req.Header.Set("Authorization", "Bearer "+a.AccessToken)
+	return nil
+}
+
+// OAuth2Token handles an OAuth 2.0 access/refresh token pair issued by HubSpot
+type OAuth2Token struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// Expired reports whether the access token is expired, or about to expire, at the given time.
+// A token without ExpiresAt never expires, it is refreshed once HubSpot rejects it.
+func (t *OAuth2Token) Expired(now time.Time) bool {
+	if t.ExpiresAt.IsZero() {
+		return false
+	}
+	return !now.Add(oAuthExpiryMargin).Before(t.ExpiresAt)
+}
+
+// TokenStore persists OAuth 2.0 tokens, so refreshed tokens survive restarts and
+// can be shared between processes. Implementations must be safe for concurrent use.
+type TokenStore interface {
+	Token(ctx context.Context) (*OAuth2Token, error)
+	SaveToken(ctx context.Context, token *OAuth2Token) error
+}
+
+// MemoryTokenStore is a TokenStore that keeps the token in memory
+type MemoryTokenStore struct {
+	mu    sync.Mutex
+	token *OAuth2Token
+}
+
+// NewMemoryTokenStore creates a new MemoryTokenStore holding the given token
+func NewMemoryTokenStore(token *OAuth2Token) *MemoryTokenStore {
+	return &MemoryTokenStore{
+		token: token,
+	}
+}
+
+// Token returns a copy of the stored token
+func (s *MemoryTokenStore) Token(ctx context.Context) (*OAuth2Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token == nil {
+		return nil, ErrNoOAuthToken
+	}
+	token := *s.token
+	return &token, nil
+}
+
+// SaveToken replaces the stored token
+func (s *MemoryTokenStore) SaveToken(ctx context.Context, token *OAuth2Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := *token
+	s.token = &t
+	return nil
+}
+
+// OAuth2Authenticator authenticates requests with an OAuth 2.0 access token sent as
+// an Authorization: Bearer header, refreshing the token against TokenURL once it expires
+// or HubSpot rejects it. The token is loaded from Store once and cached, Store is only
+// read and written again when refreshing.
+type OAuth2Authenticator struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURI  string
+	TokenURL     string
+	Store        TokenStore
+	HTTPClient   HTTPClient
+
+	mu    sync.Mutex
+	token *OAuth2Token
+}
+
+// NewOAuth2Authenticator creates a new OAuth2Authenticator for a HubSpot app
+func NewOAuth2Authenticator(clientID string, clientSecret string, store TokenStore) *OAuth2Authenticator {
+	return &OAuth2Authenticator{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		TokenURL:     DefaultOAuthTokenURL,
+		Store:        store,
+		HTTPClient:   http.DefaultClient,
+	}
+}
+
+// Authenticate adds a valid access token to the request, refreshing it first when expired
+func (a *OAuth2Authenticator) Authenticate(req *http.Request) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	token := a.token
+	if token == nil {
+		var err error
+		if token, err = a.load(req.Context()); err != nil {
+			return err
+		}
+	}
+
+	if token.AccessToken == "" || token.Expired(time.Now()) {
+		var err error
+		if token, err = a.refresh(req.Context(), token); err != nil {
+			return err
+		}
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	return nil
+}
+
+// Refresh exchanges the stored refresh token for a new access token and saves it,
+// regardless of whether the current access token is expired
+func (a *OAuth2Authenticator) Refresh(ctx context.Context) (*OAuth2Token, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	token, err := a.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return a.refresh(ctx, token)
+}
+
+// refreshRejected refreshes the access token HubSpot rejected, unless it was already
+// replaced, in the cache or in Store by another process, since the request was sent
+func (a *OAuth2Authenticator) refreshRejected(ctx context.Context, authorization string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.token != nil && "Bearer "+a.token.AccessToken != authorization {
+		return nil
+	}
+
+	token, err := a.load(ctx)
+	if err != nil {
+		return err
+	}
+	if "Bearer "+token.AccessToken != authorization {
+		return nil
+	}
+
+	_, err = a.refresh(ctx, token)
+	return err
+}
+
+// load reads the token from Store and caches it, the caller must hold a.mu
+func (a *OAuth2Authenticator) load(ctx context.Context) (*OAuth2Token, error) {
+	token, err := a.Store.Token(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load OAuth token, err: %v", err)
+	}
+	if token == nil {
+		return nil, ErrNoOAuthToken
+	}
+
+	a.token = token
+	return token, nil
+}
+
+// oAuthTokenResponse handles the response of the HubSpot token endpoint
+type oAuthTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	Message      string `json:"message"`
+}
+
+// refresh requests a new access token, the caller must hold a.mu
+func (a *OAuth2Authenticator) refresh(ctx context.Context, token *OAuth2Token) (*OAuth2Token, error) {
+	if token.RefreshToken == "" {
+		return nil, errors.New("OAuth token is expired and has no refresh token")
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("client_id", a.ClientID)
+	form.Set("client_secret", a.ClientSecret)
+	form.Set("refresh_token", token.RefreshToken)
+	if a.RedirectURI != "" {
+		form.Set("redirect_uri", a.RedirectURI)
+	}
+
+	tokenURL := a.TokenURL
+	if tokenURL == "" {
+		tokenURL = DefaultOAuthTokenURL
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("unable to build OAuth refresh request, err: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	httpClient := a.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	r, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to refresh OAuth token, err: %v", err)
+	}
+	defer r.Body.Close()
+
+	var tokenResponse oAuthTokenResponse
+	if err := json.NewDecoder(r.Body).Decode(&tokenResponse); err != nil {
+		return nil, fmt.Errorf("could not decode OAuth refresh response, err: %v", err)
+	}
+
+	if r.StatusCode != http.StatusOK || tokenResponse.AccessToken == "" {
+		return nil, fmt.Errorf("unable to refresh OAuth token, status: %d, message: %s", r.StatusCode, tokenResponse.Message)
+	}
+
+	refreshed := &OAuth2Token{
+		AccessToken:  tokenResponse.AccessToken,
+		RefreshToken: tokenResponse.RefreshToken,
+		ExpiresAt:    time.Now().Add(time.Duration(tokenResponse.ExpiresIn) * time.Second),
+	}
+	if refreshed.RefreshToken == "" {
+		refreshed.RefreshToken = token.RefreshToken
+	}
+
+	if err := a.Store.SaveToken(ctx, refreshed); err != nil {
+		return nil, fmt.Errorf("unable to save refreshed OAuth token, err: %v", err)
+	}
+
+	a.token = refreshed
+	return refreshed, nil
+}
@@ -0,0 +1,186 @@
+package hubspot_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	hubSpot "github.com/teamexos/hubspot-api-go/hubspot"
+)
+
+func newMockResponse(statusCode int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(bytes.NewReader([]byte(body))),
+	}
+}
+
+func TestPrivateAppAuthenticator(t *testing.T) {
+	c := hubSpot.NewClientWithAuthenticator(hubSpot.NewPrivateAppAuthenticator("pat-na1-token"))
+
+	var gotRequest *http.Request
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			gotRequest = req
+			return newMockResponse(http.StatusCreated, `{"id": "551"}`), nil
+		},
+	}
+
+	contact, err := c.CreateContact(hubSpot.NewContactInput(map[string]string{}))
+
+	assert.NoError(t, err, "expected empty error response")
+	assert.Equal(t, "551", contact.ID, "expected contact id to have a value")
+	assert.Equal(t, "Bearer pat-na1-token", gotRequest.Header.Get("Authorization"), "expected bearer token")
+	assert.Equal(t, "", gotRequest.URL.Query().Get("hapikey"), "expected no api key in the url")
+}
+
+func TestOAuth2AuthenticatorRefresh(t *testing.T) {
+	tests := []struct {
+		name            string
+		token           *hubSpot.OAuth2Token
+		wantRefresh     bool
+		wantAccessToken string
+	}{
+		{
+			name: "valid token",
+			token: &hubSpot.OAuth2Token{
+				AccessToken:  "valid-access-token",
+				RefreshToken: "refresh-token",
+				ExpiresAt:    time.Now().Add(time.Hour),
+			},
+			wantRefresh:     false,
+			wantAccessToken: "valid-access-token",
+		},
+		{
+			name: "expired token",
+			token: &hubSpot.OAuth2Token{
+				AccessToken:  "expired-access-token",
+				RefreshToken: "refresh-token",
+				ExpiresAt:    time.Now().Add(-time.Minute),
+			},
+			wantRefresh:     true,
+			wantAccessToken: "new-access-token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := hubSpot.NewMemoryTokenStore(tt.token)
+			authenticator := hubSpot.NewOAuth2Authenticator("client-id", "client-secret", store)
+
+			refreshed := false
+			authenticator.HTTPClient = &MockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					refreshed = true
+					assert.NoError(t, req.ParseForm())
+					assert.Equal(t, "refresh_token", req.PostForm.Get("grant_type"))
+					assert.Equal(t, "refresh-token", req.PostForm.Get("refresh_token"))
+					return newMockResponse(http.StatusOK, `{
+						"access_token": "new-access-token",
+						"refresh_token": "new-refresh-token",
+						"expires_in": 1800
+					}`), nil
+				},
+			}
+
+			req, _ := http.NewRequest(http.MethodGet, hubSpot.DefaultAPIBaseURL, nil)
+			err := authenticator.Authenticate(req)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantRefresh, refreshed, "expected refresh only for expired tokens")
+			assert.Equal(t, "Bearer "+tt.wantAccessToken, req.Header.Get("Authorization"))
+
+			saved, _ := store.Token(context.Background())
+			assert.Equal(t, tt.wantAccessToken, saved.AccessToken, "expected the token store to hold the current token")
+		})
+	}
+}
+
+// countingTokenStore counts the reads of a MemoryTokenStore
+type countingTokenStore struct {
+	*hubSpot.MemoryTokenStore
+	reads int
+}
+
+func (s *countingTokenStore) Token(ctx context.Context) (*hubSpot.OAuth2Token, error) {
+	s.reads++
+	return s.MemoryTokenStore.Token(ctx)
+}
+
+func TestOAuth2AuthenticatorCachesToken(t *testing.T) {
+	store := &countingTokenStore{MemoryTokenStore: hubSpot.NewMemoryTokenStore(&hubSpot.OAuth2Token{
+		AccessToken:  "valid-access-token",
+		RefreshToken: "refresh-token",
+		ExpiresAt:    time.Now().Add(time.Hour),
+	})}
+	authenticator := hubSpot.NewOAuth2Authenticator("client-id", "client-secret", store)
+
+	for i := 0; i < 3; i++ {
+		req, _ := http.NewRequest(http.MethodGet, hubSpot.DefaultAPIBaseURL, nil)
+		assert.NoError(t, authenticator.Authenticate(req))
+		assert.Equal(t, "Bearer valid-access-token", req.Header.Get("Authorization"))
+	}
+
+	assert.Equal(t, 1, store.reads, "expected the token store to be read once")
+}
+
+func TestOAuth2AuthenticatorRefreshOnUnauthorized(t *testing.T) {
+	// a token without expiry is only refreshed once HubSpot rejects it
+	store := hubSpot.NewMemoryTokenStore(&hubSpot.OAuth2Token{
+		AccessToken:  "revoked-access-token",
+		RefreshToken: "refresh-token",
+	})
+	authenticator := hubSpot.NewOAuth2Authenticator("client-id", "client-secret", store)
+
+	refreshes := 0
+	authenticator.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			refreshes++
+			return newMockResponse(http.StatusOK, `{"access_token": "new-access-token", "expires_in": 1800}`), nil
+		},
+	}
+
+	c := hubSpot.NewClientWithAuthenticator(authenticator)
+
+	var gotAuthorizations []string
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			gotAuthorizations = append(gotAuthorizations, req.Header.Get("Authorization"))
+			if req.Header.Get("Authorization") != "Bearer new-access-token" {
+				return newMockResponse(http.StatusUnauthorized, `{"status": "error", "category": "INVALID_AUTHENTICATION"}`), nil
+			}
+			return newMockResponse(http.StatusOK, `{"id": "3100"}`), nil
+		},
+	}
+
+	contact, err := c.ReadContact("pp@gmail.com", "email")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "3100", contact.ID)
+	assert.Equal(t, 1, refreshes)
+	assert.Equal(t, []string{"Bearer revoked-access-token", "Bearer new-access-token"}, gotAuthorizations)
+
+	saved, _ := store.Token(context.Background())
+	assert.Equal(t, "new-access-token", saved.AccessToken)
+	assert.Equal(t, "refresh-token", saved.RefreshToken, "expected the refresh token to be kept")
+
+	// a token that is still rejected after the refresh fails instead of refreshing again
+	authenticator.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			refreshes++
+			return newMockResponse(http.StatusOK, `{"access_token": "another-access-token", "expires_in": 1800}`), nil
+		},
+	}
+	c.HTTPClient = NewMockHTTPClient(http.StatusUnauthorized, `{"status": "error", "category": "INVALID_AUTHENTICATION"}`)
+
+	_, err = c.ReadContact("pp@gmail.com", "email")
+
+	assert.True(t, errors.Is(err, hubSpot.ErrUnauthorized))
+	assert.Equal(t, 2, refreshes, "expected a single refresh per request")
+}
@@ -0,0 +1,383 @@
+package hubspot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// errNoBatchResult is the error of a batch input no result or error of HubSpot could be matched to
+var errNoBatchResult = errors.New("no result returned for batch input")
+
+// BatchContactResult handles the outcome of a single input of a contact batch
+type BatchContactResult struct {
+	// Index is the position of the input in the slice passed to the batch method
+	Index int
+	// Contact is the created, read or updated contact, nil for archived or failed inputs
+	Contact *ContactOutput
+	// Err is the reason the input failed, nil when it succeeded
+	Err error
+}
+
+// BatchError is returned when some inputs of a batch failed, the error of each
+// failed input is set on its BatchContactResult
+type BatchError struct {
+	Failed int
+	Total  int
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("%d of %d batch inputs failed", e.Failed, e.Total)
+}
+
+// contactBatchResults handles the results of a contact batch request
+type contactBatchResults struct {
+	Status    string               `json:"status"`
+	Results   []batchContactOutput `json:"results"`
+	NumErrors int                  `json:"numErrors"`
+	Errors    []APIError           `json:"errors"`
+}
+
+// batchContactInput handles a contact input of a batch create, HubSpot returns the
+// objectWriteTraceId with the created contact or the error of the input
+type batchContactInput struct {
+	Properties         map[string]string `json:"properties"`
+	ObjectWriteTraceID string            `json:"objectWriteTraceId"`
+}
+
+// batchContactOutput handles a contact returned by a batch request
+type batchContactOutput struct {
+	ContactOutput
+	ObjectWriteTraceID string `json:"objectWriteTraceId,omitempty"`
+}
+
+// batchMatcher matches the results and errors of a batch response to the inputs of a chunk
+// by one kind of key, case insensitively
+type batchMatcher struct {
+	// keys identifies each input of the chunk, empty for inputs without a key
+	keys []string
+	// key returns the key of a returned contact
+	key func(contact *batchContactOutput) string
+	// errorContext is the error context entry listing the keys of failed inputs
+	errorContext string
+}
+
+// defaultContactProperties are the properties HubSpot returns for a contact when none are requested
+var defaultContactProperties = []string{"createdate", "email", "firstname", "hs_object_id", "lastmodifieddate", "lastname"}
+
+// BatchCreateContacts creates Contacts in HubSpot, in as many batch requests of BatchLimit
+// inputs as needed. Created contacts are matched to their inputs by an objectWriteTraceId sent
+// with each input, or by email address when HubSpot does not return it. With a client
+// Validator, inputs failing validation are not sent and fail with a *ValidationError.
+func (c *Client) BatchCreateContacts(inputs []*ContactInput) ([]BatchContactResult, error) {
+	return c.BatchCreateContactsWithContext(context.Background(), inputs)
+}
+
+// BatchCreateContactsWithContext creates Contacts in HubSpot in batches using the given context
+func (c *Client) BatchCreateContactsWithContext(ctx context.Context, inputs []*ContactInput) ([]BatchContactResult, error) {
+	validate := func(i int) error {
+		return c.validateContactInput(ctx, inputs[i])
+	}
+
+	return c.batchContacts(ctx, "create", http.StatusCreated, len(inputs), validate, func(indexes []int) (interface{}, []batchMatcher) {
+		chunk := make([]batchContactInput, len(indexes))
+		traceIDs := make([]string, len(indexes))
+		emails := make([]string, len(indexes))
+		for n, i := range indexes {
+			// the index of the input identifies it across the whole batch
+			traceIDs[n] = strconv.Itoa(i)
+			chunk[n].ObjectWriteTraceID = traceIDs[n]
+			if inputs[i] != nil {
+				chunk[n].Properties = inputs[i].Properties
+				emails[n] = inputs[i].Properties[ContactIDPropertyEmail]
+			}
+		}
+
+		return batchRequest{Inputs: chunk}, []batchMatcher{{
+			keys:         traceIDs,
+			key:          func(contact *batchContactOutput) string { return contact.ObjectWriteTraceID },
+			errorContext: "objectWriteTraceId",
+		}, {
+			keys:         emails,
+			key:          func(contact *batchContactOutput) string { return contact.Properties[ContactIDPropertyEmail] },
+			errorContext: "ids",
+		}}
+	})
+}
+
+// BatchReadContacts gets Contacts in HubSpot by their IDs, or by the unique property set in opts,
+// in as many batch requests of BatchLimit inputs as needed
+func (c *Client) BatchReadContacts(ids []string, opts *BatchReadOptions) ([]BatchContactResult, error) {
+	return c.BatchReadContactsWithContext(context.Background(), ids, opts)
+}
+
+// BatchReadContactsWithContext gets Contacts in HubSpot in batches using the given context
+func (c *Client) BatchReadContactsWithContext(
+	ctx context.Context,
+	ids []string,
+	opts *BatchReadOptions) ([]BatchContactResult, error) {
+
+	var properties []string
+	idProperty := ""
+	if opts != nil {
+		properties = opts.Properties
+		idProperty = opts.IDProperty
+	}
+
+	// results are matched by the id property, so make sure HubSpot returns it
+	// along with the properties it would return by default
+	if idProperty != "" {
+		if len(properties) == 0 {
+			properties = defaultContactProperties
+		}
+		if !containsString(properties, idProperty) {
+			properties = append(append([]string{}, properties...), idProperty)
+		}
+	}
+
+	return c.batchContacts(ctx, "read", http.StatusOK, len(ids), nil, func(indexes []int) (interface{}, []batchMatcher) {
+		keys := make([]string, len(indexes))
+		for n, i := range indexes {
+			keys[n] = ids[i]
+		}
+
+		matcher := batchMatcher{keys: keys, key: func(contact *batchContactOutput) string {
+			if idProperty != "" {
+				return contact.Properties[idProperty]
+			}
+			return contact.ID
+		}, errorContext: "ids"}
+
+		return batchRequest{
+			Properties: properties,
+			IDProperty: idProperty,
+			Inputs:     batchObjectIDs(keys),
+		}, []batchMatcher{matcher}
+	})
+}
+
+// BatchUpdateContacts updates Contacts in HubSpot, in as many batch requests of BatchLimit inputs as needed.
+// With a client Validator, inputs failing validation are not sent and fail with a *ValidationError.
+func (c *Client) BatchUpdateContacts(inputs []BatchUpdateInput) ([]BatchContactResult, error) {
+	return c.BatchUpdateContactsWithContext(context.Background(), inputs)
+}
+
+// BatchUpdateContactsWithContext updates Contacts in HubSpot in batches using the given context
+func (c *Client) BatchUpdateContactsWithContext(ctx context.Context, inputs []BatchUpdateInput) ([]BatchContactResult, error) {
+	validate := func(i int) error {
+		return c.validateContactInput(ctx, &ContactInput{Properties: inputs[i].Properties})
+	}
+
+	return c.batchContacts(ctx, "update", http.StatusOK, len(inputs), validate, func(indexes []int) (interface{}, []batchMatcher) {
+		chunk := make([]BatchUpdateInput, len(indexes))
+		keys := make([]string, len(indexes))
+		for n, i := range indexes {
+			chunk[n] = inputs[i]
+			keys[n] = inputs[i].ID
+		}
+
+		return batchRequest{Inputs: chunk}, []batchMatcher{{
+			keys:         keys,
+			key:          func(contact *batchContactOutput) string { return contact.ID },
+			errorContext: "ids",
+		}}
+	})
+}
+
+// BatchArchiveContacts moves Contacts in HubSpot to the recycling bin, in as many batch
+// requests of BatchLimit inputs as needed
+func (c *Client) BatchArchiveContacts(ids []string) ([]BatchContactResult, error) {
+	return c.BatchArchiveContactsWithContext(context.Background(), ids)
+}
+
+// BatchArchiveContactsWithContext moves Contacts in HubSpot to the recycling bin in batches using the given context
+func (c *Client) BatchArchiveContactsWithContext(ctx context.Context, ids []string) ([]BatchContactResult, error) {
+	results := newBatchContactResults(len(ids))
+	batchURL := c.buildURL(c.objectPath(ObjectTypeContacts, "")+"/batch/archive", nil)
+
+	for start := 0; start < len(ids); start += BatchLimit {
+		end := batchEnd(start, len(ids))
+
+		err := ctx.Err()
+		if err == nil {
+			err = c.do(ctx, http.MethodPost, batchURL, batchRequest{Inputs: batchObjectIDs(ids[start:end])}, http.StatusNoContent, nil)
+		}
+		for i := start; i < end; i++ {
+			results[i].Err = err
+		}
+	}
+
+	return results, batchResultsError(results)
+}
+
+// batchContacts sends the inputs of a contact batch operation in chunks of BatchLimit. validate,
+// when not nil, checks every input first and inputs failing with a *ValidationError are left
+// out of their chunk. body returns the request body for the inputs at indexes and the
+// matchers mapping returned contacts and errors back to them, in order of preference.
+func (c *Client) batchContacts(
+	ctx context.Context,
+	operation string,
+	wantStatus int,
+	size int,
+	validate func(i int) error,
+	body func(indexes []int) (interface{}, []batchMatcher)) ([]BatchContactResult, error) {
+
+	results := newBatchContactResults(size)
+	batchURL := c.buildURL(c.objectPath(ObjectTypeContacts, "")+"/batch/"+operation, nil)
+
+	if validate != nil {
+		for i := range results {
+			err := validate(i)
+			var validationErr *ValidationError
+			if err != nil && !errors.As(err, &validationErr) {
+				// the property definitions could not be loaded, no input can be validated
+				failBatchChunk(results, err)
+				return results, batchResultsError(results)
+			}
+			results[i].Err = err
+		}
+	}
+
+	for start := 0; start < size; start += BatchLimit {
+		var indexes []int
+		for i := start; i < batchEnd(start, size); i++ {
+			if results[i].Err == nil {
+				indexes = append(indexes, i)
+			}
+		}
+		if len(indexes) == 0 {
+			continue
+		}
+
+		chunk := make([]BatchContactResult, len(indexes))
+		requestBody, matchers := body(indexes)
+
+		var chunkResults contactBatchResults
+		err := ctx.Err()
+		if err == nil {
+			err = c.doBatch(ctx, batchURL, requestBody, wantStatus, &chunkResults)
+		}
+		if err != nil {
+			failBatchChunk(chunk, err)
+		} else {
+			matchBatchChunk(chunk, matchers, &chunkResults)
+		}
+
+		for n, i := range indexes {
+			results[i].Contact, results[i].Err = chunk[n].Contact, chunk[n].Err
+		}
+	}
+
+	return results, batchResultsError(results)
+}
+
+// matchBatchChunk sets the outcome of every input of a chunk from the batch response, trying
+// each matcher in turn. Inputs no result or error can be matched to fail with errNoBatchResult
+// rather than being handed an outcome that may belong to another input.
+func matchBatchChunk(results []BatchContactResult, matchers []batchMatcher, chunkResults *contactBatchResults) {
+	indexes := make([]map[string][]int, len(matchers))
+	for m, matcher := range matchers {
+		indexes[m] = map[string][]int{}
+		for i, k := range matcher.keys {
+			if k != "" {
+				k = strings.ToLower(k)
+				indexes[m][k] = append(indexes[m][k], i)
+			}
+		}
+	}
+
+	// takeIndex returns the first input with the key of matcher m that has no outcome yet
+	takeIndex := func(m int, k string) (int, bool) {
+		k = strings.ToLower(k)
+		for n, i := range indexes[m][k] {
+			if results[i].Contact == nil && results[i].Err == nil {
+				indexes[m][k] = indexes[m][k][n+1:]
+				return i, true
+			}
+		}
+		return 0, false
+	}
+
+	for n := range chunkResults.Results {
+		contact := &chunkResults.Results[n]
+		for m, matcher := range matchers {
+			if i, ok := takeIndex(m, matcher.key(contact)); ok {
+				results[i].Contact = &contact.ContactOutput
+				break
+			}
+		}
+	}
+
+	for _, apiError := range chunkResults.Errors {
+		for m, matcher := range matchers {
+			matched := false
+			for _, k := range apiError.Context[matcher.errorContext] {
+				if i, ok := takeIndex(m, k); ok {
+					inputError := apiError
+					results[i].Err = &inputError
+					matched = true
+				}
+			}
+			if matched {
+				break
+			}
+		}
+	}
+
+	for i := range results {
+		if results[i].Contact == nil && results[i].Err == nil {
+			results[i].Err = errNoBatchResult
+		}
+	}
+}
+
+// newBatchContactResults creates a result for every input of a batch
+func newBatchContactResults(size int) []BatchContactResult {
+	results := make([]BatchContactResult, size)
+	for i := range results {
+		results[i].Index = i
+	}
+	return results
+}
+
+// failBatchChunk sets the error of every input of a chunk
+func failBatchChunk(results []BatchContactResult, err error) {
+	for i := range results {
+		results[i].Err = err
+	}
+}
+
+// batchResultsError returns a *BatchError when any input of a batch failed
+func batchResultsError(results []BatchContactResult) error {
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+		}
+	}
+	if failed == 0 {
+		return nil
+	}
+	return &BatchError{Failed: failed, Total: len(results)}
+}
+
+// batchEnd returns the end of the chunk starting at start
+func batchEnd(start int, size int) int {
+	if start+BatchLimit < size {
+		return start + BatchLimit
+	}
+	return size
+}
+
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
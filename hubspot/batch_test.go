@@ -0,0 +1,208 @@
+package hubspot_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	hubSpot "github.com/teamexos/hubspot-api-go/hubspot"
+)
+
+func TestBatchCreateContacts(t *testing.T) {
+	c := hubSpot.NewClient("fake-api-key")
+
+	var inputs []*hubSpot.ContactInput
+	for i := 0; i < 250; i++ {
+		inputs = append(inputs, hubSpot.NewContactInput(map[string]string{
+			"email": fmt.Sprintf("user%d@marvel.com", i),
+		}))
+	}
+
+	var chunkSizes []int
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "/crm/v3/objects/contacts/batch/create", req.URL.Path)
+
+			var body struct {
+				Inputs []hubSpot.ContactInput `json:"inputs"`
+			}
+			raw, _ := ioutil.ReadAll(req.Body)
+			assert.NoError(t, json.Unmarshal(raw, &body))
+			chunkSizes = append(chunkSizes, len(body.Inputs))
+
+			// answer in reverse order, with the email in upper case, to exercise matching
+			var results []string
+			for i := len(body.Inputs) - 1; i >= 0; i-- {
+				email := body.Inputs[i].Properties["email"]
+				results = append(results, fmt.Sprintf(`{"id": "id-%s", "properties": {"email": "%s"}}`,
+					email, strings.ToUpper(email)))
+			}
+			return newMockResponse(http.StatusCreated, fmt.Sprintf(`{
+				"status": "COMPLETE",
+				"results": [%s]
+			}`, strings.Join(results, ","))), nil
+		},
+	}
+
+	results, err := c.BatchCreateContacts(inputs)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{100, 100, 50}, chunkSizes, "expected the inputs to be sent in chunks of 100")
+	assert.Len(t, results, 250)
+	for i, result := range results {
+		assert.Equal(t, i, result.Index)
+		assert.Equal(t, fmt.Sprintf("id-user%d@marvel.com", i), result.Contact.ID, "expected results in input order")
+	}
+}
+
+func TestBatchCreateContactsByTraceID(t *testing.T) {
+	c := hubSpot.NewClient("fake-api-key")
+
+	var gotBody string
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			raw, _ := ioutil.ReadAll(req.Body)
+			gotBody = string(raw)
+			return newMockResponse(http.StatusMultiStatus, `{
+				"status": "COMPLETE",
+				"results": [
+					{"id": "552", "objectWriteTraceId": "2", "properties": {"firstname": "Tony"}},
+					{"id": "551", "objectWriteTraceId": "0", "properties": {"firstname": "Peter"}}
+				],
+				"numErrors": 1,
+				"errors": [
+					{
+						"status": "error",
+						"category": "VALIDATION_ERROR",
+						"message": "Property values were not valid",
+						"context": {"objectWriteTraceId": ["1"]}
+					}
+				]
+			}`), nil
+		},
+	}
+
+	results, err := c.BatchCreateContacts([]*hubSpot.ContactInput{
+		hubSpot.NewContactInput(map[string]string{"firstname": "Peter"}),
+		hubSpot.NewContactInput(map[string]string{"firstname": "Bruce", "exos_sessions": "twelve"}),
+		hubSpot.NewContactInput(map[string]string{"firstname": "Tony"}),
+	})
+
+	assert.EqualError(t, err, "1 of 3 batch inputs failed")
+	assert.JSONEq(t, `{"inputs": [
+		{"properties": {"firstname": "Peter"}, "objectWriteTraceId": "0"},
+		{"properties": {"firstname": "Bruce", "exos_sessions": "twelve"}, "objectWriteTraceId": "1"},
+		{"properties": {"firstname": "Tony"}, "objectWriteTraceId": "2"}
+	]}`, gotBody, "expected the input index as trace id")
+	assert.Equal(t, "551", results[0].Contact.ID, "expected inputs without an email to be matched")
+	var apiErr *hubSpot.APIError
+	if assert.True(t, errors.As(results[1].Err, &apiErr), "expected the error matched by trace id") {
+		assert.Equal(t, hubSpot.CategoryValidationError, apiErr.Category)
+	}
+	assert.Equal(t, "552", results[2].Contact.ID)
+}
+
+func TestBatchReadContactsPartialFailure(t *testing.T) {
+	c := hubSpot.NewClient("fake-api-key")
+
+	c.HTTPClient = NewMockHTTPClient(http.StatusMultiStatus, `{
+		"status": "COMPLETE",
+		"results": [
+			{"id": "3100", "properties": {"email": "pp@gmail.com"}}
+		],
+		"numErrors": 1,
+		"errors": [
+			{
+				"status": "error",
+				"category": "OBJECT_NOT_FOUND",
+				"message": "Could not get some CONTACT objects, they may be deleted or not exist.",
+				"context": {"ids": ["404", "405"]}
+			}
+		]
+	}`)
+
+	results, err := c.BatchReadContacts([]string{"404", "3100", "405"}, nil)
+
+	var batchErr *hubSpot.BatchError
+	assert.True(t, errors.As(err, &batchErr), "expected a batch error")
+	assert.Equal(t, 2, batchErr.Failed)
+	assert.Equal(t, 3, batchErr.Total)
+
+	assert.True(t, errors.Is(results[0].Err, hubSpot.ErrNotFound))
+	assert.Nil(t, results[0].Contact)
+	assert.NoError(t, results[1].Err)
+	assert.Equal(t, "3100", results[1].Contact.ID)
+	assert.True(t, errors.Is(results[2].Err, hubSpot.ErrNotFound))
+}
+
+func TestBatchArchiveContactsChunkFailure(t *testing.T) {
+	c := hubSpot.NewClient("fake-api-key")
+
+	calls := 0
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 2 {
+				return newMockResponse(http.StatusBadRequest, `{"status": "error", "category": "VALIDATION_ERROR"}`), nil
+			}
+			return newMockResponse(http.StatusNoContent, ""), nil
+		},
+	}
+
+	ids := make([]string, 150)
+	for i := range ids {
+		ids[i] = fmt.Sprint(i)
+	}
+
+	results, err := c.BatchArchiveContacts(ids)
+
+	assert.EqualError(t, err, "50 of 150 batch inputs failed")
+	assert.NoError(t, results[99].Err)
+	assert.True(t, errors.Is(results[100].Err, hubSpot.ErrBadRequest))
+	assert.True(t, errors.Is(results[149].Err, hubSpot.ErrBadRequest))
+}
+
+func TestBatchReadContactsByIDProperty(t *testing.T) {
+	c := hubSpot.NewClient("fake-api-key")
+
+	var gotBody struct {
+		Properties []string `json:"properties"`
+		IDProperty string   `json:"idProperty"`
+	}
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			raw, _ := ioutil.ReadAll(req.Body)
+			assert.NoError(t, json.Unmarshal(raw, &gotBody))
+			return newMockResponse(http.StatusMultiStatus, `{
+				"status": "COMPLETE",
+				"results": [
+					{"id": "3100", "properties": {"email": "pp@gmail.com"}},
+					{"id": "3200", "properties": {"email": "unknown@gmail.com"}}
+				],
+				"numErrors": 1,
+				"errors": [
+					{"status": "error", "category": "OBJECT_NOT_FOUND", "message": "Could not get some CONTACT objects"}
+				]
+			}`), nil
+		},
+	}
+
+	results, err := c.BatchReadContacts([]string{"MJ@gmail.com", "PP@Gmail.com"}, &hubSpot.BatchReadOptions{
+		IDProperty: "email",
+	})
+
+	assert.EqualError(t, err, "1 of 2 batch inputs failed")
+	assert.Equal(t, "email", gotBody.IDProperty)
+	assert.Contains(t, gotBody.Properties, "email", "expected the id property to be requested")
+	assert.Contains(t, gotBody.Properties, "firstname", "expected HubSpot's default properties to be requested")
+	assert.Nil(t, results[0].Contact, "expected unmatched results not to be handed to another input")
+	assert.Error(t, results[0].Err)
+	assert.False(t, errors.Is(results[0].Err, hubSpot.ErrNotFound), "expected unmatched errors not to be handed out")
+	assert.NoError(t, results[1].Err)
+	assert.Equal(t, "3100", results[1].Contact.ID, "expected ids to be matched case insensitively")
+}
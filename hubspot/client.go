@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 )
@@ -23,45 +24,55 @@ type HTTPClient interface {
 	Do(req *http.Request) (*http.Response, error)
 }
 
-// AssociationErrorResponse handles the HubSpot error when associating two objects
-type AssociationErrorResponse struct {
-	Status     string
-	StatusCode int
-	Message    string
-	NumErrors  int
-	Errors     []ErrorResponse
-}
-
 // Client allows you to create a new HubSpot client
 type Client struct {
 	APIBaseURL string
-	APIKey     string
-	APIVersion string
-	HTTPClient HTTPClient
-}
-
-// ErrorResponse handles the error structure returned by HubSpot API
-type ErrorResponse struct {
-	Category      string
-	CorrelationID string
-	Links         map[string]string
-	Message       string
-	Status        string
-	StatusCode    int
+	// APIKey is the legacy HubSpot API key, sent as the hapikey query parameter when set
+	APIKey        string
+	APIVersion    string
+	Authenticator Authenticator
+	HTTPClient    HTTPClient
+	// RateLimiter paces requests to HubSpot's reported quota, nil disables pacing
+	RateLimiter *RateLimiter
+	// RetryPolicy controls how failed requests are retried, nil disables retries
+	RetryPolicy *RetryPolicy
+	// Validator checks contact properties before contacts are created or updated, nil disables validation
+	Validator *PropertyValidator
 }
 
 // Response handles a response by the request method
 type Response struct {
 	Body       json.RawMessage
+	Header     http.Header
 	StatusCode int
+
+	// authorization is the Authorization header the request was sent with
+	authorization string
 }
 
 // NewClient creates a new HubSpot Client with corresponding defaults
+// that authenticates with a legacy API key
 func NewClient(apiKey string) *Client {
-	c := &Client{}
+	c := newClient()
 	c.APIKey = apiKey
+	return c
+}
+
+// NewClientWithAuthenticator creates a new HubSpot Client with corresponding defaults
+// that authenticates every request with the given Authenticator, for example
+// a PrivateAppAuthenticator or an OAuth2Authenticator
+func NewClientWithAuthenticator(authenticator Authenticator) *Client {
+	c := newClient()
+	c.Authenticator = authenticator
+	return c
+}
+
+// newClient creates a new HubSpot Client without credentials
+func newClient() *Client {
+	c := &Client{}
 	c.APIBaseURL = DefaultAPIBaseURL
 	c.APIVersion = DefaultAPIVersion
+	c.RateLimiter = NewRateLimiter()
 
 	// Instantiate gzip client with a 5 second timeout on waiting for the
 	// remote server to accept the connection and a 30 second timeout
@@ -81,10 +92,6 @@ func NewClient(apiKey string) *Client {
 	return c
 }
 
-func (e ErrorResponse) Error() string {
-	return fmt.Sprintf(e.Message)
-}
-
 // BuildAssociationURL returns the URL for HubSpot object to object associations
 func BuildAssociationURL(c *Client, from string, to string) (string, error) {
 	from = strings.TrimSpace(from)
@@ -92,196 +99,480 @@ func BuildAssociationURL(c *Client, from string, to string) (string, error) {
 	if len(from) == 0 || len(to) == 0 {
 		return "", fmt.Errorf("BuildAssociationURL(): from and to arguments require a value")
 	}
-	return fmt.Sprintf("%s/crm/%s/associations/%s/%s/batch/create?hapikey=%s", c.APIBaseURL, c.APIVersion, from, to, c.APIKey), nil
+	return c.buildURL(fmt.Sprintf("/crm/%s/associations/%s/%s/batch/create", c.APIVersion, from, to), nil), nil
 }
 
 // CreateAssociation relates two objects to each other in HubSpot
-func (c *Client) CreateAssociation(association *AssociationInput, from string, to string) (*AssociationResults, AssociationErrorResponse) {
+func (c *Client) CreateAssociation(association *AssociationInput, from string, to string) (*AssociationResults, error) {
+	return c.CreateAssociationWithContext(context.Background(), association, from, to)
+}
 
-	requestBody, err := json.Marshal(association)
-	if err != nil {
-		return nil, AssociationErrorResponse{Status: "error", Message: "invalid association input"}
-	}
+// CreateAssociationWithContext relates two objects to each other in HubSpot using the given context
+func (c *Client) CreateAssociationWithContext(
+	ctx context.Context,
+	association *AssociationInput,
+	from string,
+	to string) (*AssociationResults, error) {
 
 	requestURL, err := BuildAssociationURL(c, from, to)
 	if err != nil {
-		return nil, AssociationErrorResponse{Status: "error", Message: fmt.Sprintf("unable to build url, err: %v", err)}
+		return nil, fmt.Errorf("unable to build url, err: %w", err)
+	}
+
+	var associationResult AssociationResults
+	if err := c.do(ctx, http.MethodPost, requestURL, association, http.StatusCreated, &associationResult); err != nil {
+		return nil, err
 	}
 
-	r, err := c.request(
-		requestURL,
-		http.MethodPost,
-		requestBody)
+	return &associationResult, nil
+}
 
-	if err != nil {
-		return nil,
-			AssociationErrorResponse{Status: "error", Message: fmt.Sprintf("unable to execute request, err: %v", err)}
+// CreateContact creates a new Contact in HubSpot
+func (c *Client) CreateContact(contactInput *ContactInput) (*ContactOutput, error) {
+	return c.CreateContactWithContext(context.Background(), contactInput)
+}
+
+// CreateContactWithContext creates a new Contact in HubSpot using the given context
+func (c *Client) CreateContactWithContext(ctx context.Context, contactInput *ContactInput) (*ContactOutput, error) {
+	if err := c.validateContactInput(ctx, contactInput); err != nil {
+		return nil, err
 	}
 
-	if r.StatusCode != http.StatusCreated {
-		var errorResponse AssociationErrorResponse
-		err := json.Unmarshal(r.Body, &errorResponse)
-		errorResponse.StatusCode = r.StatusCode
+	apiURL := c.buildURL(c.objectPath(ObjectTypeContacts, ""), nil)
 
-		if err != nil {
-			errorResponse.Status = "error"
-			errorResponse.Message = fmt.Sprintf("unable to unmarshal HubSpot association error response, err: %v", err)
-		}
-		return nil, errorResponse
+	var contactOutput ContactOutput
+	if err := c.do(ctx, http.MethodPost, apiURL, contactInput, http.StatusCreated, &contactOutput); err != nil {
+		return nil, err
 	}
 
-	var associationResult AssociationResults
-	if err := json.Unmarshal(r.Body, &associationResult); err != nil {
-		msg := fmt.Sprintf("could not unmarshal HubSpot response, err: %v", err)
-		return nil, AssociationErrorResponse{Status: "error", Message: msg}
+	return &contactOutput, nil
+}
+
+// UpdateContact updates a Contact in HubSpot
+func (c *Client) UpdateContact(contactID string, contactInput *ContactInput) (*ContactOutput, error) {
+	return c.UpdateContactWithContext(context.Background(), contactID, contactInput)
+}
+
+// UpdateContactWithContext updates a Contact in HubSpot using the given context
+func (c *Client) UpdateContactWithContext(
+	ctx context.Context,
+	contactID string,
+	contactInput *ContactInput) (*ContactOutput, error) {
+
+	if err := c.validateContactInput(ctx, contactInput); err != nil {
+		return nil, err
+	}
+
+	apiURL := c.buildURL(c.objectPath(ObjectTypeContacts, contactID), nil)
+
+	var contactOutput ContactOutput
+	if err := c.do(ctx, http.MethodPatch, apiURL, contactInput, http.StatusOK, &contactOutput); err != nil {
+		return nil, err
 	}
 
-	return &associationResult, AssociationErrorResponse{}
+	return &contactOutput, nil
 }
 
-// CreateContact creates a new Contact in HubSpot
-func (c *Client) CreateContact(contactInput *ContactInput) (*ContactOutput, ErrorResponse) {
-	requestBody, err := json.Marshal(contactInput)
-	if err != nil {
-		return nil, ErrorResponse{Status: "error", Message: "invalid contact input"}
+// ReadContact gets a Contact in HubSpot
+// email is the address of the user being searched for
+// properties is a comma separated string (no spaces!) of the properties (firstname,email,..) to be returned in the response
+func (c *Client) ReadContact(email string, properties string) (*ContactOutput, error) {
+	return c.ReadContactWithContext(context.Background(), email, properties)
+}
+
+// ReadContactWithContext gets a Contact in HubSpot using the given context
+func (c *Client) ReadContactWithContext(ctx context.Context, email string, properties string) (*ContactOutput, error) {
+	opts := &ReadOptions{}
+	if properties != "" {
+		opts.Properties = strings.Split(properties, ",")
 	}
-	r, err := c.request(
-		fmt.Sprintf("%s/crm/%s/objects/contacts/?hapikey=%s", c.APIBaseURL, c.APIVersion, c.APIKey),
-		http.MethodPost,
-		requestBody)
+	return c.ReadContactWithOptionsWithContext(ctx, email, opts)
+}
 
-	if err != nil {
-		return nil,
-			ErrorResponse{Status: "error", Message: fmt.Sprintf("unable to execute request, err: %v", err)}
+// ReadContactWithOptions gets a Contact in HubSpot by email address, with the properties, property
+// history, associations and archived state requested in opts. The email address is the id property,
+// use ReadContactByIDProperty to read by another unique property.
+func (c *Client) ReadContactWithOptions(email string, opts *ReadOptions) (*ContactOutput, error) {
+	return c.ReadContactWithOptionsWithContext(context.Background(), email, opts)
+}
+
+// ReadContactWithOptionsWithContext gets a Contact in HubSpot by email address using the given context
+func (c *Client) ReadContactWithOptionsWithContext(
+	ctx context.Context,
+	email string,
+	opts *ReadOptions) (*ContactOutput, error) {
+
+	if opts != nil && opts.IDProperty != "" && opts.IDProperty != ContactIDPropertyEmail {
+		return nil, fmt.Errorf("contact read by email cannot use id property %q", opts.IDProperty)
 	}
 
-	if r.StatusCode != http.StatusCreated {
-		var errorResponse ErrorResponse
-		err := json.Unmarshal(r.Body, &errorResponse)
-		errorResponse.StatusCode = r.StatusCode
+	return c.ReadContactByIDPropertyWithContext(ctx, ContactIDPropertyEmail, email, opts)
+}
 
-		if err != nil {
-			errorResponse.Status = "error"
-			errorResponse.Message = fmt.Sprintf("unable to unmarshal HubSpot create account error response, err: %v", err)
-		}
-		return nil, errorResponse
+// ReadContactByID gets a Contact in HubSpot by its ID
+func (c *Client) ReadContactByID(contactID string, opts *ReadOptions) (*ContactOutput, error) {
+	return c.ReadContactByIDWithContext(context.Background(), contactID, opts)
+}
+
+// ReadContactByIDWithContext gets a Contact in HubSpot by its ID using the given context
+func (c *Client) ReadContactByIDWithContext(
+	ctx context.Context,
+	contactID string,
+	opts *ReadOptions) (*ContactOutput, error) {
+
+	query := opts.query()
+	query.Del("idProperty")
+	return c.readContact(ctx, contactID, query)
+}
+
+// ReadContactByIDProperty gets a Contact in HubSpot by the value of a unique property,
+// e.g. ContactIDPropertyEmail or a unique custom property
+func (c *Client) ReadContactByIDProperty(idProperty string, value string, opts *ReadOptions) (*ContactOutput, error) {
+	return c.ReadContactByIDPropertyWithContext(context.Background(), idProperty, value, opts)
+}
+
+// ReadContactByIDPropertyWithContext gets a Contact in HubSpot by the value of a unique property using the given context
+func (c *Client) ReadContactByIDPropertyWithContext(
+	ctx context.Context,
+	idProperty string,
+	value string,
+	opts *ReadOptions) (*ContactOutput, error) {
+
+	if idProperty == "" {
+		return nil, errors.New("contact read by id property requires an id property")
+	}
+
+	query := opts.query()
+	query.Set("idProperty", idProperty)
+	return c.readContact(ctx, value, query)
+}
+
+// readContact gets a Contact in HubSpot identified by id and the query parameters
+func (c *Client) readContact(ctx context.Context, id string, query url.Values) (*ContactOutput, error) {
+	if err := checkObjectID("contact", id); err != nil {
+		return nil, err
 	}
 
+	apiURL := c.buildURL(c.objectPath(ObjectTypeContacts, id), query)
+
 	var contactOutput ContactOutput
-	if err := json.Unmarshal(r.Body, &contactOutput); err != nil {
-		msg := fmt.Sprintf("could not unmarshal HubSpot response, err: %v", err)
-		return nil, ErrorResponse{Status: "error", Message: msg}
+	if err := c.do(ctx, http.MethodGet, apiURL, nil, http.StatusOK, &contactOutput); err != nil {
+		return nil, err
 	}
 
-	return &contactOutput, ErrorResponse{}
+	return &contactOutput, nil
 }
 
-// UpdateContact updates a Contact in HubSpot
-func (c *Client) UpdateContact(contactID string, contactInput *ContactInput) (*ContactOutput, ErrorResponse) {
-	requestBody, err := json.Marshal(contactInput)
-	if err != nil {
-		return nil, ErrorResponse{Status: "error", Message: "invalid contact input"}
+// checkObjectID makes sure an object identifier has a value, an empty one would
+// address the collection rather than a single object
+func checkObjectID(object string, id string) error {
+	if strings.TrimSpace(id) == "" {
+		return fmt.Errorf("%s identifier requires a value", object)
 	}
+	return nil
+}
 
-	apiURL := fmt.Sprintf("%s/crm/%s/objects/contacts/%s?hapikey=%s", c.APIBaseURL, c.APIVersion, contactID, c.APIKey)
-	r, err := c.request(apiURL, http.MethodPatch, requestBody)
+// DeleteContact deletes a Contact in HubSpot by moving it to the recycling bin,
+// use GDPRDeleteContact to permanently delete a Contact and its data
+func (c *Client) DeleteContact(contactID string) error {
+	return c.DeleteContactWithContext(context.Background(), contactID)
+}
 
-	if err != nil {
-		return nil,
-			ErrorResponse{Status: "error", Message: fmt.Sprintf("unable to execute request, err: %v", err)}
+// DeleteContactWithContext deletes a Contact in HubSpot using the given context
+func (c *Client) DeleteContactWithContext(ctx context.Context, contactID string) error {
+	apiURL := c.buildURL(c.objectPath(ObjectTypeContacts, contactID), nil)
+
+	// StatusNoContent means that hubspot succeeded, though it will succeed for any numeric value, an alphanumeric will create a 404 response
+	return c.do(ctx, http.MethodDelete, apiURL, nil, http.StatusNoContent, nil)
+}
+
+// UpsertContact creates a Contact in HubSpot, or updates the existing Contact with the same
+// value for idProperty (e.g. ContactIDPropertyEmail), which must be set in contactInput. When the
+// create conflicts on another unique property, the conflict error is returned and nothing is updated.
+func (c *Client) UpsertContact(idProperty string, contactInput *ContactInput) (*UpsertContactOutput, error) {
+	return c.UpsertContactWithContext(context.Background(), idProperty, contactInput)
+}
+
+// UpsertContactWithContext creates or updates a Contact in HubSpot using the given context
+func (c *Client) UpsertContactWithContext(
+	ctx context.Context,
+	idProperty string,
+	contactInput *ContactInput) (*UpsertContactOutput, error) {
+
+	if contactInput == nil || contactInput.Properties[idProperty] == "" {
+		return nil, fmt.Errorf("contact input requires a value for id property %q", idProperty)
 	}
 
-	if r.StatusCode != http.StatusOK {
-		var errorResponse ErrorResponse
-		err := json.Unmarshal(r.Body, &errorResponse)
-		errorResponse.StatusCode = r.StatusCode
-		if err != nil {
-			errorResponse.Status = "error"
-			errorResponse.Message = fmt.Sprintf("unable to unmarshal HubSpot update account error response, err: %v", err)
+	contactOutput, err := c.CreateContactWithContext(ctx, contactInput)
+	if err == nil {
+		return &UpsertContactOutput{Contact: contactOutput, Created: true}, nil
+	}
+	if !errors.Is(err, ErrConflict) {
+		return nil, err
+	}
+
+	// the conflict may be on another unique property than idProperty, e.g. the email address,
+	// so resolve the existing contact by idProperty rather than the ID named in the message
+	var contactID string
+	existing, readErr := c.ReadContactByIDPropertyWithContext(ctx, idProperty, contactInput.Properties[idProperty], nil)
+	switch {
+	case readErr == nil:
+		contactID = existing.ID
+	case idProperty != ContactIDPropertyEmail && errors.Is(readErr, ErrNotFound):
+		// no contact holds the idProperty value, another contact conflicts on a different property
+		return nil, err
+	case idProperty != ContactIDPropertyEmail:
+		// the ID in the conflict message may belong to a contact conflicting on another property
+		return nil, fmt.Errorf("unable to find the existing contact, err: %w", readErr)
+	default:
+		// an email conflict names the contact with the same email, fall back to its ID
+		var ok bool
+		if contactID, ok = existingObjectID(err); !ok {
+			return nil, fmt.Errorf("unable to find the existing contact, err: %w", readErr)
 		}
+	}
+
+	contactOutput, err = c.UpdateContactWithContext(ctx, contactID, contactInput)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UpsertContactOutput{Contact: contactOutput, Created: false}, nil
+}
+
+// MergeContacts merges the secondary Contact into the primary Contact in HubSpot and returns
+// the merged Contact, which keeps the primary Contact's ID
+func (c *Client) MergeContacts(primaryContactID string, secondaryContactID string) (*ContactOutput, error) {
+	return c.MergeContactsWithContext(context.Background(), primaryContactID, secondaryContactID)
+}
+
+// MergeContactsWithContext merges two Contacts in HubSpot using the given context
+func (c *Client) MergeContactsWithContext(
+	ctx context.Context,
+	primaryContactID string,
+	secondaryContactID string) (*ContactOutput, error) {
+
+	if primaryContactID == "" || secondaryContactID == "" {
+		return nil, errors.New("merging contacts requires a primary and a secondary contact id")
+	}
 
-		return nil, errorResponse
+	apiURL := c.buildURL(c.objectPath(ObjectTypeContacts, "")+"/merge", nil)
+	mergeInput := &MergeInput{
+		PrimaryObjectID: primaryContactID,
+		ObjectIDToMerge: secondaryContactID,
 	}
 
 	var contactOutput ContactOutput
-	if err := json.Unmarshal(r.Body, &contactOutput); err != nil {
-		msg := fmt.Sprintf("could not unmarshal HubSpot response, err: %v", err)
-		return nil, ErrorResponse{Status: "error", Message: msg}
+	if err := c.do(ctx, http.MethodPost, apiURL, mergeInput, http.StatusOK, &contactOutput); err != nil {
+		return nil, err
 	}
 
-	return &contactOutput, ErrorResponse{}
+	return &contactOutput, nil
 }
 
-// ReadContact gets a Contact in HubSpot
-// email is the address of the user being searched for
-// properties is a comma separated string (no spaces!) of the properties (firstname,email,..) to be returned in the response
-func (c *Client) ReadContact(email string, properties string) (*ContactOutput, ErrorResponse) {
+// RecreateArchivedContact creates a new Contact in HubSpot from an archived Contact. HubSpot's API
+// cannot move a record out of the recycling bin, so this is not an undo of DeleteContact: the new
+// Contact has a new ID, and the associations and property history of the archived one are lost.
+// Only the given properties are copied, or HubSpot's default property set when empty, skipping
+// the properties the portal defines as read-only.
+func (c *Client) RecreateArchivedContact(contactID string, properties []string) (*ContactOutput, error) {
+	return c.RecreateArchivedContactWithContext(context.Background(), contactID, properties)
+}
 
-	apiURL := fmt.Sprintf("%s/crm/%s/objects/contacts/%s?hapikey=%s&idProperty=email", c.APIBaseURL, c.APIVersion, email, c.APIKey)
-	r, err := c.request(apiURL, http.MethodGet, nil)
+// RecreateArchivedContactWithContext creates a new Contact in HubSpot from an archived Contact
+// using the given context
+func (c *Client) RecreateArchivedContactWithContext(
+	ctx context.Context,
+	contactID string,
+	properties []string) (*ContactOutput, error) {
+
+	archived, err := c.ReadContactByIDWithContext(ctx, contactID, &ReadOptions{
+		Properties: properties,
+		Archived:   true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read archived contact, err: %w", err)
+	}
+	if !archived.Archived {
+		return nil, fmt.Errorf("contact %s is not archived", contactID)
+	}
 
+	definitions, err := c.Properties(ObjectTypeContacts).List(ctx, nil)
 	if err != nil {
-		return nil,
-			ErrorResponse{
-				StatusCode: r.StatusCode,
-				Status:     "error",
-				Message:    fmt.Sprintf("unable to execute request, err: %v", err),
-			}
+		return nil, fmt.Errorf("unable to list contact properties, err: %w", err)
+	}
+	writable := map[string]bool{}
+	for i := range definitions {
+		writable[definitions[i].Name] = !definitions[i].IsReadOnly()
 	}
 
-	if r.StatusCode != http.StatusOK {
-		var errorResponse ErrorResponse
-		err := json.Unmarshal(r.Body, &errorResponse)
-		errorResponse.StatusCode = r.StatusCode
-		if err != nil {
-			errorResponse.Status = "error"
-			errorResponse.Message = fmt.Sprintf("unable to unmarshal HubSpot read account error response, err: %v", err)
+	recreatedProperties := map[string]string{}
+	for name, value := range archived.Properties {
+		if value != "" && writable[name] {
+			recreatedProperties[name] = value
 		}
+	}
+
+	return c.CreateContactWithContext(ctx, NewContactInput(recreatedProperties))
+}
+
+// GDPRDeleteContact permanently deletes a Contact and its data in HubSpot to comply with GDPR,
+// id is the contact ID, or the value of idProperty when it is set (e.g. ContactIDPropertyEmail).
+// Unlike DeleteContact, the Contact cannot be restored.
+func (c *Client) GDPRDeleteContact(id string, idProperty string) error {
+	return c.GDPRDeleteContactWithContext(context.Background(), id, idProperty)
+}
 
-		return nil, errorResponse
+// GDPRDeleteContactWithContext permanently deletes a Contact in HubSpot using the given context
+func (c *Client) GDPRDeleteContactWithContext(ctx context.Context, id string, idProperty string) error {
+	if strings.TrimSpace(id) == "" {
+		return errors.New("contact identifier requires a value")
 	}
 
-	var contactOutput ContactOutput
-	if err := json.Unmarshal(r.Body, &contactOutput); err != nil {
-		msg := fmt.Sprintf("could not unmarshal HubSpot response, err: %v", err)
-		return nil, ErrorResponse{Status: "error", Message: msg}
+	apiURL := c.buildURL(c.objectPath(ObjectTypeContacts, "")+"/gdpr-delete", nil)
+	gdprDeleteInput := &GDPRDeleteInput{
+		ObjectID:   id,
+		IDProperty: idProperty,
+	}
+
+	return c.do(ctx, http.MethodPost, apiURL, gdprDeleteInput, http.StatusNoContent, nil)
+}
+
+// buildURL returns the absolute URL of an API path with the given query parameters,
+// including the legacy API key when the client has one
+func (c *Client) buildURL(path string, query url.Values) string {
+	if query == nil {
+		query = url.Values{}
+	}
+	if c.APIKey != "" {
+		query.Set("hapikey", c.APIKey)
 	}
 
-	return &contactOutput, ErrorResponse{}
+	apiURL := c.APIBaseURL + path
+	if len(query) > 0 {
+		apiURL += "?" + query.Encode()
+	}
+	return apiURL
 }
 
-// DeleteContact deletes a Contact in HubSpot
-func (c *Client) DeleteContact(contactID string) ErrorResponse {
+// do executes a HTTP request with in encoded as the JSON request body, and decodes the
+// response body into out. Any status code other than wantStatus or one of otherStatuses is
+// returned as an *APIError.
+func (c *Client) do(
+	ctx context.Context,
+	method string,
+	apiURL string,
+	in interface{},
+	wantStatus int,
+	out interface{},
+	otherStatuses ...int) error {
 
-	apiURL := fmt.Sprintf("%s/crm/%s/objects/contacts/%s?hapikey=%s", c.APIBaseURL, c.APIVersion, contactID, c.APIKey)
-	r, err := c.request(apiURL, http.MethodDelete, nil)
+	r, err := c.send(ctx, method, apiURL, in)
+
+	// HubSpot answered, any decoding failure of an error body is handled by newAPIError
+	if r != nil && r.StatusCode != 0 && !acceptsStatus(r.StatusCode, wantStatus, otherStatuses) {
+		return newAPIError(r)
+	}
 
 	if err != nil {
-		return ErrorResponse{
-			StatusCode: r.StatusCode,
-			Status:     "error",
-			Message:    fmt.Sprintf("unable to execute request, err: %v", err),
+		return err
+	}
+
+	return decodeResponse(r, out)
+}
+
+// doBatch executes a batch HTTP request like do, but also decodes a multi-status
+// response into out, which carries the errors of the failed inputs
+func (c *Client) doBatch(ctx context.Context, apiURL string, in interface{}, wantStatus int, out interface{}) error {
+	return c.do(ctx, http.MethodPost, apiURL, in, wantStatus, out, http.StatusMultiStatus)
+}
+
+// acceptsStatus reports whether statusCode is wantStatus or one of otherStatuses
+func acceptsStatus(statusCode int, wantStatus int, otherStatuses []int) bool {
+	if statusCode == wantStatus {
+		return true
+	}
+	for _, status := range otherStatuses {
+		if statusCode == status {
+			return true
 		}
 	}
+	return false
+}
 
-	// StatusNoContent means that hubspot succeeded, though it will succeed for any numeric value, an alphanumeric will create a 404 response
-	if r.StatusCode != http.StatusNoContent {
-		var errorResponse ErrorResponse
-		err := json.Unmarshal(r.Body, &errorResponse)
-		errorResponse.StatusCode = r.StatusCode
+// send encodes in as the JSON request body and executes a HTTP request
+func (c *Client) send(ctx context.Context, method string, apiURL string, in interface{}) (*Response, error) {
+	var requestBody []byte
+	if in != nil {
+		var err error
+		requestBody, err = json.Marshal(in)
 		if err != nil {
-			errorResponse.Status = "error"
-			errorResponse.Message = fmt.Sprintf("unable to unmarshal HubSpot delete account error response, err: %v", err)
+			return nil, fmt.Errorf("invalid request input, err: %w", err)
 		}
+	}
+
+	return c.request(ctx, apiURL, method, requestBody)
+}
+
+// decodeResponse decodes the body of a successful response into out
+func decodeResponse(r *Response, out interface{}) error {
+	if out == nil || len(r.Body) == 0 {
+		return nil
+	}
 
-		return errorResponse
+	if err := json.Unmarshal(r.Body, out); err != nil {
+		return fmt.Errorf("could not unmarshal HubSpot response, err: %w", err)
 	}
 
-	return ErrorResponse{}
+	return nil
 }
 
-// request executes a HTTP request and returns the response
+// objectPath returns the API path of a CRM object type, or of a single object when id is set
+func (c *Client) objectPath(objectType string, id string) string {
+	path := fmt.Sprintf("/crm/%s/objects/%s", c.APIVersion, objectType)
+	if id != "" {
+		path += "/" + url.PathEscape(id)
+	}
+	return path
+}
+
+// request executes a HTTP request, retrying it according to the client RetryPolicy,
+// and returns the response
 func (c *Client) request(
+	ctx context.Context,
+	url string,
+	method string,
+	requestBody []byte) (*Response, error) {
+
+	refreshed := false
+	for attempt := 0; ; attempt++ {
+		response, err := c.attempt(ctx, url, method, requestBody)
+
+		// an OAuth access token rejected before its expiry is refreshed and the request repeated once
+		if authenticator, ok := c.Authenticator.(*OAuth2Authenticator); ok && !refreshed &&
+			response != nil && response.StatusCode == http.StatusUnauthorized {
+
+			refreshed = true
+			if refreshErr := authenticator.refreshRejected(ctx, response.authorization); refreshErr != nil {
+				return response, err
+			}
+			continue
+		}
+
+		delay, retry := c.RetryPolicy.backoff(method, attempt, response, err)
+		if !retry {
+			return response, err
+		}
+
+		if sleepErr := sleepContext(ctx, delay); sleepErr != nil {
+			return response, err
+		}
+	}
+}
+
+// attempt executes a single HTTP request and returns the response
+func (c *Client) attempt(
+	ctx context.Context,
 	url string,
 	method string,
 	requestBody []byte) (*Response, error) {
@@ -289,26 +580,40 @@ func (c *Client) request(
 	var response Response
 
 	// Timeout the entire request after 30 seconds if the server accepts the connection
-	// but never responds
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	// but never responds, an earlier deadline on ctx takes precedence
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 
 	defer cancel()
 
 	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewBuffer(requestBody))
 	if err != nil {
-		return nil, errors.New("request execution failed")
+		return &response, fmt.Errorf("unable to build request, err: %w", err)
 	}
 
 	req.Header.Add("Content-Type", "application/json")
+	if c.Authenticator != nil {
+		if err := c.Authenticator.Authenticate(req); err != nil {
+			return &response, fmt.Errorf("request authentication failed: %w", err)
+		}
+		response.authorization = req.Header.Get("Authorization")
+	}
+
+	if err := c.RateLimiter.wait(ctx); err != nil {
+		return &response, err
+	}
+
 	r, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return &response, errors.New("request execution failed")
+		return &response, &transportError{err: err}
 	}
 
 	defer r.Body.Close()
 
+	c.RateLimiter.observe(r.StatusCode, r.Header)
+
 	// prepare response
 	response.StatusCode = r.StatusCode
+	response.Header = r.Header
 
 	// a delete response returns StatusNoContent, for example, so end after finding this
 	if response.StatusCode == http.StatusNoContent {
@@ -316,7 +621,7 @@ func (c *Client) request(
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&response.Body); err != nil {
-		return &response, errors.New("ERROR: could not decode response")
+		return &response, fmt.Errorf("could not decode response, err: %w", err)
 	}
 
 	return &response, nil
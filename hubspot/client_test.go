@@ -2,10 +2,14 @@ package hubspot_test
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	hubSpot "github.com/teamexos/hubspot-api-go/hubspot"
@@ -26,6 +30,10 @@ func NewMockHTTPClient(wantResponseCode int, wantResponse string) *MockHTTPClien
 }
 
 func (m *MockHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	if m.DoFunc != nil {
+		return m.DoFunc(req)
+	}
+
 	r := ioutil.NopCloser(bytes.NewReader([]byte(m.wantResponse)))
 	return &http.Response{
 		StatusCode: m.wantResponseCode,
@@ -49,8 +57,11 @@ func TestUnauthorized(t *testing.T) {
 
 	_, err := c.CreateContact(hubSpot.NewContactInput(map[string]string{}))
 
-	assert.Equal(t, "error", err.Status, "expected unauthorized error")
-	assert.Equal(t, http.StatusUnauthorized, err.StatusCode, "expected 401 unauthorized error")
+	var apiErr *hubSpot.APIError
+	assert.True(t, errors.As(err, &apiErr), "expected an API error")
+	assert.True(t, errors.Is(err, hubSpot.ErrUnauthorized), "expected unauthorized error")
+	assert.Equal(t, "error", apiErr.Status, "expected unauthorized error")
+	assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode, "expected 401 unauthorized error")
 }
 
 func TestCreateContact(t *testing.T) {
@@ -83,8 +94,17 @@ func TestCreateContact(t *testing.T) {
 		}`)
 
 	contact, err := c.CreateContact(hubSpot.NewContactInput(properties))
-	assert.Equal(t, 0, err.StatusCode, "expected empty error response")
+	assert.NoError(t, err, "expected empty error response")
 	assert.NotEqual(t, "", contact.ID, "expected contact id to have a value")
+	assert.Equal(t, time.Date(2020, time.August, 20, 15, 47, 54, 554000000, time.UTC), contact.CreatedAt.UTC(),
+		"expected created at to be decoded")
+	assert.Equal(t, time.Date(2020, time.August, 20, 15, 47, 54, 870000000, time.UTC), contact.UpdatedAt.UTC(),
+		"expected updated at to be decoded")
+	assert.True(t, contact.ArchivedAt.IsZero(), "expected no archived at")
+
+	lastModified, err := contact.DateTimeProperty("lastmodifieddate")
+	assert.NoError(t, err)
+	assert.True(t, contact.UpdatedAt.Equal(lastModified), "expected lastmodifieddate to be parsed")
 }
 
 func TestCreateContactErrors(t *testing.T) {
@@ -105,6 +125,7 @@ func TestCreateContactErrors(t *testing.T) {
 		json              string
 		wantStatusCode    int
 		wantErrorCategory string
+		wantErr           error
 	}{
 		{
 			name: "contactAlreadyExists",
@@ -116,6 +137,7 @@ func TestCreateContactErrors(t *testing.T) {
 			}`,
 			wantStatusCode:    http.StatusConflict,
 			wantErrorCategory: "CONFLICT",
+			wantErr:           hubSpot.ErrConflict,
 		},
 		{
 			name: "badRequest",
@@ -127,6 +149,7 @@ func TestCreateContactErrors(t *testing.T) {
 			}`,
 			wantStatusCode:    http.StatusBadRequest,
 			wantErrorCategory: "VALIDATION_ERROR",
+			wantErr:           hubSpot.ErrBadRequest,
 		},
 	}
 
@@ -136,8 +159,12 @@ func TestCreateContactErrors(t *testing.T) {
 			tt.json,
 		)
 		_, err := c.CreateContact(wantContact)
-		assert.Equal(t, tt.wantStatusCode, err.StatusCode, "expected status codes to match")
-		assert.Equal(t, tt.wantErrorCategory, err.Category, "expected proper error category")
+
+		var apiErr *hubSpot.APIError
+		assert.True(t, errors.As(err, &apiErr), "expected an API error")
+		assert.Equal(t, tt.wantStatusCode, apiErr.StatusCode, "expected status codes to match")
+		assert.Equal(t, tt.wantErrorCategory, apiErr.Category, "expected proper error category")
+		assert.True(t, errors.Is(err, tt.wantErr), "expected error to match the sentinel")
 	}
 }
 
@@ -169,7 +196,7 @@ func TestUpdateContact(t *testing.T) {
 		}`)
 
 	contact, err := c.UpdateContact("ContactID", hubSpot.NewContactInput(properties))
-	assert.Equal(t, 0, err.StatusCode, "expected empty error status code")
+	assert.NoError(t, err, "expected empty error")
 	assert.NotEqual(t, "", contact.ID, "expected a value for contact id")
 }
 
@@ -208,8 +235,11 @@ func TestUpdateContactErrors(t *testing.T) {
 		)
 		contactId := "someContactId"
 		_, err := c.UpdateContact(contactId, wantContact)
-		assert.Equal(t, tt.wantStatusCode, err.StatusCode, "expected matching error codes")
-		assert.Equal(t, tt.wantErrorCategory, err.Category, "expected matching error categories")
+
+		var apiErr *hubSpot.APIError
+		assert.True(t, errors.As(err, &apiErr), "expected an API error")
+		assert.Equal(t, tt.wantStatusCode, apiErr.StatusCode, "expected matching error codes")
+		assert.Equal(t, tt.wantErrorCategory, apiErr.Category, "expected matching error categories")
 	}
 }
 
@@ -249,9 +279,10 @@ func TestCreateAssociation(t *testing.T) {
 	wantAssociation := hubSpot.NewSingleContactToCompanyAssociationInput(contactID, companyID)
 	association, err := c.CreateAssociation(wantAssociation, "contact", "company")
 
-	assert.Equal(t, 0, err.StatusCode, "expected empty error response, got error with status code")
+	assert.NoError(t, err, "expected empty error response")
 	assert.Greater(t, len(association.Results), 0, "expected an array of associations")
 	assert.Equal(t, contactID, association.Results[1].From.ID, "expected return contact id to match with sent value")
+	assert.Equal(t, "COMPLETE", association.Status, "expected the batch status to be decoded")
 }
 
 func TestCreateAssociationErrors(t *testing.T) {
@@ -349,9 +380,12 @@ func TestCreateAssociationErrors(t *testing.T) {
 		)
 		_, err := c.CreateAssociation(wantAssociation, "contact", "company")
 
-		assert.Equal(t, tt.wantStatusCode, err.StatusCode, "expected error codes to match")
-		assert.Equal(t, tt.wantErrorCategory, err.Errors[0].Category, "expected error codes to match")
-		assert.Len(t, err.Errors, tt.wantNumErrors, "expected number of errors")
+		var apiErr *hubSpot.APIError
+		assert.True(t, errors.As(err, &apiErr), "expected an API error")
+		assert.Equal(t, tt.wantStatusCode, apiErr.StatusCode, "expected error codes to match")
+		assert.Equal(t, tt.wantErrorCategory, apiErr.Errors[0].Category, "expected error codes to match")
+		assert.Len(t, apiErr.Errors, tt.wantNumErrors, "expected number of errors")
+		assert.True(t, errors.Is(err, hubSpot.ErrNotFound), "expected not found errors")
 	}
 }
 
@@ -452,8 +486,8 @@ func TestReadContact(t *testing.T) {
 
 			contactOutput, hserr := c.ReadContact(tt.wantEmail, tt.properties)
 
-			if hserr.Status != "" {
-				assert.Equal(t, http.StatusNotFound, hserr.StatusCode)
+			if hserr != nil {
+				assert.True(t, errors.Is(hserr, hubSpot.ErrNotFound), "expected not found error")
 			} else {
 				assert.Equal(t, tt.wantID, contactOutput.ID, "ensure the proper hubspot user ID")
 				assert.Equal(t, tt.wantEmail, contactOutput.Properties["email"], "ensure the correct email address")
@@ -492,13 +526,491 @@ func TestDeleteContact(t *testing.T) {
 			hserr := c.DeleteContact(tt.wantID)
 
 			if tt.wantStatusCode == http.StatusNotFound {
-				assert.Equal(t, http.StatusNotFound, hserr.StatusCode, "")
+				assert.True(t, errors.Is(hserr, hubSpot.ErrNotFound), "expected not found error")
 
 			} else {
-				assert.Equal(t, "", hserr.Status, "ensure the function deleted ")
+				assert.NoError(t, hserr, "ensure the function deleted ")
+			}
+
+		})
+	}
+
+}
+
+func TestContextCancellation(t *testing.T) {
+	c := hubSpot.NewClient("fake-api-key")
+
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			<-req.Context().Done()
+			return nil, req.Context().Err()
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := c.CreateContactWithContext(ctx, hubSpot.NewContactInput(map[string]string{}))
+
+	assert.True(t, errors.Is(err, context.Canceled), "expected the cancellation to be preserved")
+}
+
+func TestReadContactWithOptions(t *testing.T) {
+	c := hubSpot.NewClient("fake-api-key")
+
+	var gotRequest *http.Request
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			gotRequest = req
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(`{
+					"id": "3100",
+					"properties": {
+						"email": "pp@gmail.com",
+						"exos_perform_account_verified": "true"
+					},
+					"propertiesWithHistory": {
+						"exos_perform_account_verified": [
+							{
+								"value": "true",
+								"timestamp": "2020-10-14T18:03:10.772Z",
+								"sourceType": "INTEGRATION",
+								"sourceId": "123456"
+							},
+							{
+								"value": "false",
+								"timestamp": "2020-10-14T18:01:05.763Z",
+								"sourceType": "INTEGRATION",
+								"sourceId": "123456"
+							}
+						]
+					},
+					"associations": {
+						"companies": {
+							"results": [
+								{
+									"id": "4705054985",
+									"type": "contact_to_company"
+								}
+							]
+						}
+					},
+					"archived": false
+				}`))),
+			}, nil
+		},
+	}
+
+	contact, err := c.ReadContactWithOptions("pp@gmail.com", &hubSpot.ReadOptions{
+		Properties:            []string{"email", "exos_perform_account_verified"},
+		PropertiesWithHistory: []string{"exos_perform_account_verified"},
+		Associations:          []string{"companies"},
+		Archived:              true,
+	})
+
+	assert.NoError(t, err)
+
+	query := gotRequest.URL.Query()
+	assert.Equal(t, "email", query.Get("idProperty"))
+	assert.Equal(t, "email,exos_perform_account_verified", query.Get("properties"))
+	assert.Equal(t, "exos_perform_account_verified", query.Get("propertiesWithHistory"))
+	assert.Equal(t, "companies", query.Get("associations"))
+	assert.Equal(t, "true", query.Get("archived"))
+
+	history := contact.PropertiesWithHistory["exos_perform_account_verified"]
+	assert.Len(t, history, 2, "expected the property history")
+	assert.Equal(t, "false", history[1].Value)
+	assert.Equal(t, "4705054985", contact.Associations["companies"].Results[0].ID)
+
+	gotRequest = nil
+	_, err = c.ReadContactWithOptionsWithContext(context.Background(), "pp@gmail.com",
+		&hubSpot.ReadOptions{IDProperty: "exos_user_id"})
+
+	assert.EqualError(t, err, `contact read by email cannot use id property "exos_user_id"`)
+	assert.Nil(t, gotRequest, "expected no request to be sent")
+}
+
+func TestReadContactProperties(t *testing.T) {
+	c := hubSpot.NewClient("fake-api-key")
+
+	var gotRequest *http.Request
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			gotRequest = req
+			return NewMockHTTPClient(http.StatusOK, `{"id": "3100"}`).Do(req)
+		},
+	}
+
+	_, err := c.ReadContact("pp@gmail.com", "firstname,email,exos_perform_account_verified")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "firstname,email,exos_perform_account_verified", gotRequest.URL.Query().Get("properties"),
+		"expected the requested properties to be sent")
+}
+
+func TestReadContactByIdentifier(t *testing.T) {
+	c := hubSpot.NewClient("fake-api-key")
+
+	tests := []struct {
+		name           string
+		read           func() (*hubSpot.ContactOutput, error)
+		wantPath       string
+		wantIDProperty string
+		wantErrorMsg   string
+		wantNoRequest  bool
+	}{
+		{
+			name: "by id",
+			read: func() (*hubSpot.ContactOutput, error) {
+				return c.ReadContactByID("3100", &hubSpot.ReadOptions{IDProperty: "email"})
+			},
+			wantPath:       "/crm/v3/objects/contacts/3100",
+			wantIDProperty: "",
+		},
+		{
+			name: "by unique property",
+			read: func() (*hubSpot.ContactOutput, error) {
+				return c.ReadContactByIDProperty("exos_user_id", "team/42 b", nil)
+			},
+			wantPath:       "/crm/v3/objects/contacts/team%2F42%20b",
+			wantIDProperty: "exos_user_id",
+		},
+		{
+			name: "by email",
+			read: func() (*hubSpot.ContactOutput, error) {
+				return c.ReadContact("pp+marvel@gmail.com", "")
+			},
+			wantPath:       "/crm/v3/objects/contacts/pp+marvel@gmail.com",
+			wantIDProperty: "email",
+		},
+		{
+			name: "empty identifier",
+			read: func() (*hubSpot.ContactOutput, error) {
+				return c.ReadContactByID(" ", nil)
+			},
+			wantErrorMsg:  "contact identifier requires a value",
+			wantNoRequest: true,
+		},
+		{
+			name: "empty id property",
+			read: func() (*hubSpot.ContactOutput, error) {
+				return c.ReadContactByIDProperty("", "team/42", nil)
+			},
+			wantErrorMsg:  "contact read by id property requires an id property",
+			wantNoRequest: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotRequest *http.Request
+			c.HTTPClient = &MockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					gotRequest = req
+					return NewMockHTTPClient(http.StatusOK, `{"id": "3100"}`).Do(req)
+				},
+			}
+
+			contact, err := tt.read()
+
+			if tt.wantNoRequest {
+				assert.EqualError(t, err, tt.wantErrorMsg)
+				assert.Nil(t, gotRequest, "expected no request to be sent")
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "3100", contact.ID)
+			assert.Equal(t, tt.wantPath, gotRequest.URL.EscapedPath(), "expected the identifier to be escaped")
+			assert.Equal(t, tt.wantIDProperty, gotRequest.URL.Query().Get("idProperty"))
+		})
+	}
+}
+
+func TestUpsertContact(t *testing.T) {
+	contactJSON := `{"id": "551", "properties": {"email": "pp@gmail.com", "exos_user_id": "u-1", "firstname": "Peter"}}`
+
+	tests := []struct {
+		name        string
+		idProperty  string
+		responses   []string
+		wantCreated bool
+		wantErrCode int
+		wantCalls   []string
+	}{
+		{
+			name:        "created",
+			idProperty:  hubSpot.ContactIDPropertyEmail,
+			responses:   []string{"201"},
+			wantCreated: true,
+			wantCalls:   []string{"POST /crm/v3/objects/contacts"},
+		},
+		{
+			name:        "conflict resolved by id property",
+			idProperty:  hubSpot.ContactIDPropertyEmail,
+			responses:   []string{"409 Contact already exists. Existing ID: 999", "200", "200"},
+			wantCreated: false,
+			wantCalls: []string{
+				"POST /crm/v3/objects/contacts",
+				"GET /crm/v3/objects/contacts/pp@gmail.com",
+				"PATCH /crm/v3/objects/contacts/551",
+			},
+		},
+		{
+			name:        "conflict resolved by existing id when the read fails",
+			idProperty:  hubSpot.ContactIDPropertyEmail,
+			responses:   []string{"409 Contact already exists. Existing ID: 551", "404", "200"},
+			wantCreated: false,
+			wantCalls: []string{
+				"POST /crm/v3/objects/contacts",
+				"GET /crm/v3/objects/contacts/pp@gmail.com",
+				"PATCH /crm/v3/objects/contacts/551",
+			},
+		},
+		{
+			name:        "conflict by custom id property",
+			idProperty:  "exos_user_id",
+			responses:   []string{"409 Contact already exists. Existing ID: 999", "200", "200"},
+			wantCreated: false,
+			wantCalls: []string{
+				"POST /crm/v3/objects/contacts",
+				"GET /crm/v3/objects/contacts/u-1",
+				"PATCH /crm/v3/objects/contacts/551",
+			},
+		},
+		{
+			name:        "conflict by custom id property when the read fails",
+			idProperty:  "exos_user_id",
+			responses:   []string{"409 Contact already exists. Existing ID: 999", "503"},
+			wantErrCode: http.StatusServiceUnavailable,
+			wantCalls: []string{
+				"POST /crm/v3/objects/contacts",
+				"GET /crm/v3/objects/contacts/u-1",
+			},
+		},
+		{
+			name:        "conflict on another property than the custom id property",
+			idProperty:  "exos_user_id",
+			responses:   []string{"409 Contact already exists. Existing ID: 999", "404"},
+			wantErrCode: http.StatusConflict,
+			wantCalls: []string{
+				"POST /crm/v3/objects/contacts",
+				"GET /crm/v3/objects/contacts/u-1",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := hubSpot.NewClient("fake-api-key")
+
+			var gotCalls []string
+			c.HTTPClient = &MockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					response := tt.responses[len(gotCalls)]
+					gotCalls = append(gotCalls, req.Method+" "+req.URL.Path)
+
+					switch {
+					case response == "201":
+						return NewMockHTTPClient(http.StatusCreated, contactJSON).Do(req)
+					case response == "200":
+						return NewMockHTTPClient(http.StatusOK, contactJSON).Do(req)
+					case response == "503":
+						return NewMockHTTPClient(http.StatusServiceUnavailable, `{"status": "error", "category": "SERVICE_UNAVAILABLE"}`).Do(req)
+					case response == "404":
+						return NewMockHTTPClient(http.StatusNotFound, `{"status": "error", "category": "OBJECT_NOT_FOUND"}`).Do(req)
+					default:
+						return NewMockHTTPClient(http.StatusConflict, fmt.Sprintf(`{
+							"status": "error",
+							"message": "%s",
+							"category": "CONFLICT"
+						}`, response[4:])).Do(req)
+					}
+				},
 			}
 
+			upserted, err := c.UpsertContact(tt.idProperty, hubSpot.NewContactInput(map[string]string{
+				"email":        "pp@gmail.com",
+				"exos_user_id": "u-1",
+				"firstname":    "Peter",
+			}))
+
+			assert.Equal(t, tt.wantCalls, gotCalls)
+			if tt.wantErrCode != 0 {
+				var apiErr *hubSpot.APIError
+				if assert.True(t, errors.As(err, &apiErr), "expected an API error, got %v", err) {
+					assert.Equal(t, tt.wantErrCode, apiErr.StatusCode)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantCreated, upserted.Created)
+			assert.Equal(t, "551", upserted.Contact.ID)
 		})
 	}
+}
+
+func TestUpsertContactRequiresIDProperty(t *testing.T) {
+	c := hubSpot.NewClient("fake-api-key")
+
+	_, err := c.UpsertContact("exos_user_id", hubSpot.NewContactInput(map[string]string{"email": "pp@gmail.com"}))
+
+	assert.EqualError(t, err, `contact input requires a value for id property "exos_user_id"`)
+}
+
+func TestMergeContacts(t *testing.T) {
+	c := hubSpot.NewClient("fake-api-key")
+
+	var gotBody hubSpot.MergeInput
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, http.MethodPost, req.Method)
+			assert.Equal(t, "/crm/v3/objects/contacts/merge", req.URL.Path)
+			body, _ := ioutil.ReadAll(req.Body)
+			assert.NoError(t, json.Unmarshal(body, &gotBody))
+			return NewMockHTTPClient(http.StatusOK, `{
+				"id": "551",
+				"properties": {
+					"email": "pp@gmail.com",
+					"work_email": "pp@marvel.com"
+				},
+				"archived": false
+			}`).Do(req)
+		},
+	}
+
+	contact, err := c.MergeContacts("551", "552")
+
+	assert.NoError(t, err)
+	assert.Equal(t, hubSpot.MergeInput{PrimaryObjectID: "551", ObjectIDToMerge: "552"}, gotBody)
+	assert.Equal(t, "551", contact.ID, "expected the merged contact to keep the primary id")
+	assert.Equal(t, "pp@marvel.com", contact.Properties["work_email"])
+}
+
+func TestGDPRDeleteContact(t *testing.T) {
+	c := hubSpot.NewClient("fake-api-key")
+
+	tests := []struct {
+		name       string
+		id         string
+		idProperty string
+		wantBody   string
+	}{
+		{
+			name:     "by id",
+			id:       "3100",
+			wantBody: `{"objectId":"3100"}`,
+		},
+		{
+			name:       "by email",
+			id:         "pp@gmail.com",
+			idProperty: hubSpot.ContactIDPropertyEmail,
+			wantBody:   `{"objectId":"pp@gmail.com","idProperty":"email"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotBody []byte
+			c.HTTPClient = &MockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, http.MethodPost, req.Method)
+					assert.Equal(t, "/crm/v3/objects/contacts/gdpr-delete", req.URL.Path)
+					gotBody, _ = ioutil.ReadAll(req.Body)
+					return NewMockHTTPClient(http.StatusNoContent, "").Do(req)
+				},
+			}
+
+			err := c.GDPRDeleteContact(tt.id, tt.idProperty)
+
+			assert.NoError(t, err, "ensure the function permanently deleted the contact")
+			assert.JSONEq(t, tt.wantBody, string(gotBody))
+		})
+	}
+}
+
+func TestRecreateArchivedContact(t *testing.T) {
+	c := hubSpot.NewClient("fake-api-key")
+
+	var gotCalls []string
+	var gotBody hubSpot.ContactInput
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			gotCalls = append(gotCalls, req.Method+" "+req.URL.Path+"?"+req.URL.Query().Encode())
+
+			if req.URL.Path == "/crm/v3/properties/contacts" {
+				return NewMockHTTPClient(http.StatusOK, `{"results": [
+					{"name": "createdate", "modificationMetadata": {"readOnlyValue": true}},
+					{"name": "email"},
+					{"name": "exos_total_sessions", "calculated": true},
+					{"name": "firstname"},
+					{"name": "hs_object_id", "modificationMetadata": {"readOnlyValue": true}},
+					{"name": "lastmodifieddate", "modificationMetadata": {"readOnlyValue": true}},
+					{"name": "lastname"}
+				]}`).Do(req)
+			}
+			if req.Method == http.MethodGet {
+				return NewMockHTTPClient(http.StatusOK, `{
+					"id": "3100",
+					"properties": {
+						"createdate": "2020-10-14T18:01:05.763Z",
+						"email": "pp@gmail.com",
+						"exos_total_sessions": "12",
+						"exos_removed_property": "gone",
+						"firstname": "Peter",
+						"hs_object_id": "3100",
+						"lastmodifieddate": "2020-10-14T18:03:10.772Z",
+						"lastname": ""
+					},
+					"createdAt": "2020-10-14T18:01:05.763Z",
+					"updatedAt": "2020-10-15T09:12:00.000Z",
+					"archivedAt": "2020-10-15T09:12:00.000Z",
+					"archived": true
+				}`).Do(req)
+			}
+
+			body, _ := ioutil.ReadAll(req.Body)
+			assert.NoError(t, json.Unmarshal(body, &gotBody))
+			return NewMockHTTPClient(http.StatusCreated, `{
+				"id": "3200",
+				"properties": {"email": "pp@gmail.com", "firstname": "Peter"},
+				"archived": false
+			}`).Do(req)
+		},
+	}
+
+	contact, err := c.RecreateArchivedContact("3100", []string{"email", "firstname", "lastname"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"GET /crm/v3/objects/contacts/3100?archived=true&hapikey=fake-api-key&properties=email%2Cfirstname%2Clastname",
+		"GET /crm/v3/properties/contacts?hapikey=fake-api-key",
+		"POST /crm/v3/objects/contacts?hapikey=fake-api-key",
+	}, gotCalls)
+	assert.Equal(t, map[string]string{"email": "pp@gmail.com", "firstname": "Peter"}, gotBody.Properties,
+		"expected only defined, writable properties with a value to be copied")
+	assert.Equal(t, "3200", contact.ID)
+}
+
+func TestReadArchivedContact(t *testing.T) {
+	c := hubSpot.NewClient("fake-api-key")
+	c.HTTPClient = NewMockHTTPClient(http.StatusOK, `{
+		"id": "3100",
+		"properties": {"email": "pp@gmail.com"},
+		"archivedAt": "2020-10-15T09:12:00.000Z",
+		"archived": true
+	}`)
+
+	contact, err := c.ReadContactByID("3100", &hubSpot.ReadOptions{Archived: true})
+
+	assert.NoError(t, err)
+	assert.True(t, contact.Archived)
+	assert.Equal(t, time.Date(2020, time.October, 15, 9, 12, 0, 0, time.UTC), contact.ArchivedAt.UTC())
+
+	c.HTTPClient = NewMockHTTPClient(http.StatusOK, `{"id": "3100", "archived": false}`)
+
+	_, err = c.RecreateArchivedContact("3100", nil)
 
+	assert.EqualError(t, err, "contact 3100 is not archived")
 }
@@ -0,0 +1,130 @@
+package hubspot
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Company object type and id properties
+const (
+	ObjectTypeCompanies = "companies"
+
+	// CompanyIDPropertyDomain reads a company by its domain instead of its ID
+	CompanyIDPropertyDomain = "domain"
+)
+
+// CompanyInput handles a company body representation from HubSpot
+type CompanyInput struct {
+	Properties map[string]string `json:"properties"`
+}
+
+// CompanyOutput handles a company representation from HubSpot
+type CompanyOutput struct {
+	ID         string            `json:"id"`
+	Properties map[string]string `json:"properties"`
+	CreatedAt  time.Time         `json:"createdAt"`
+	UpdatedAt  time.Time         `json:"updatedAt"`
+	Archived   bool              `json:"archived"`
+	ArchivedAt time.Time         `json:"archivedAt"`
+}
+
+// NewCompanyInput creates a new Company Body representation
+func NewCompanyInput(properties map[string]string) *CompanyInput {
+	return &CompanyInput{
+		Properties: properties,
+	}
+}
+
+// CreateCompany creates a new Company in HubSpot
+func (c *Client) CreateCompany(companyInput *CompanyInput) (*CompanyOutput, error) {
+	return c.CreateCompanyWithContext(context.Background(), companyInput)
+}
+
+// CreateCompanyWithContext creates a new Company in HubSpot using the given context
+func (c *Client) CreateCompanyWithContext(ctx context.Context, companyInput *CompanyInput) (*CompanyOutput, error) {
+	apiURL := c.buildURL(c.objectPath(ObjectTypeCompanies, ""), nil)
+
+	var companyOutput CompanyOutput
+	if err := c.do(ctx, http.MethodPost, apiURL, companyInput, http.StatusCreated, &companyOutput); err != nil {
+		return nil, err
+	}
+
+	return &companyOutput, nil
+}
+
+// ReadCompany gets a Company in HubSpot
+// id is the company ID, or the value of idProperty when it is set (e.g. CompanyIDPropertyDomain)
+// properties is a comma separated string (no spaces!) of the properties (name,domain,..) to be returned in the response
+func (c *Client) ReadCompany(id string, idProperty string, properties string) (*CompanyOutput, error) {
+	return c.ReadCompanyWithContext(context.Background(), id, idProperty, properties)
+}
+
+// ReadCompanyWithContext gets a Company in HubSpot using the given context
+func (c *Client) ReadCompanyWithContext(
+	ctx context.Context,
+	id string,
+	idProperty string,
+	properties string) (*CompanyOutput, error) {
+
+	if err := checkObjectID("company", id); err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	if idProperty != "" {
+		query.Set("idProperty", idProperty)
+	}
+	if properties != "" {
+		query.Set("properties", properties)
+	}
+	apiURL := c.buildURL(c.objectPath(ObjectTypeCompanies, id), query)
+
+	var companyOutput CompanyOutput
+	if err := c.do(ctx, http.MethodGet, apiURL, nil, http.StatusOK, &companyOutput); err != nil {
+		return nil, err
+	}
+
+	return &companyOutput, nil
+}
+
+// UpdateCompany updates a Company in HubSpot
+func (c *Client) UpdateCompany(companyID string, companyInput *CompanyInput) (*CompanyOutput, error) {
+	return c.UpdateCompanyWithContext(context.Background(), companyID, companyInput)
+}
+
+// UpdateCompanyWithContext updates a Company in HubSpot using the given context
+func (c *Client) UpdateCompanyWithContext(
+	ctx context.Context,
+	companyID string,
+	companyInput *CompanyInput) (*CompanyOutput, error) {
+
+	if err := checkObjectID("company", companyID); err != nil {
+		return nil, err
+	}
+
+	apiURL := c.buildURL(c.objectPath(ObjectTypeCompanies, companyID), nil)
+
+	var companyOutput CompanyOutput
+	if err := c.do(ctx, http.MethodPatch, apiURL, companyInput, http.StatusOK, &companyOutput); err != nil {
+		return nil, err
+	}
+
+	return &companyOutput, nil
+}
+
+// ArchiveCompany moves a Company in HubSpot to the recycling bin
+func (c *Client) ArchiveCompany(companyID string) error {
+	return c.ArchiveCompanyWithContext(context.Background(), companyID)
+}
+
+// ArchiveCompanyWithContext moves a Company in HubSpot to the recycling bin using the given context
+func (c *Client) ArchiveCompanyWithContext(ctx context.Context, companyID string) error {
+	if err := checkObjectID("company", companyID); err != nil {
+		return err
+	}
+
+	apiURL := c.buildURL(c.objectPath(ObjectTypeCompanies, companyID), nil)
+	return c.do(ctx, http.MethodDelete, apiURL, nil, http.StatusNoContent, nil)
+}
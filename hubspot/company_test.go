@@ -0,0 +1,141 @@
+package hubspot_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	hubSpot "github.com/teamexos/hubspot-api-go/hubspot"
+)
+
+const companyJSON = `{
+	"id": "4705054985",
+	"properties": {
+		"createdate": "2020-10-29T12:30:11.425Z",
+		"domain": "marvel.com",
+		"hs_lastmodifieddate": "2020-10-29T12:30:11.425Z",
+		"hs_object_id": "4705054985",
+		"name": "Marvel"
+	},
+	"createdAt": "2020-10-29T12:30:11.425Z",
+	"updatedAt": "2020-10-29T12:30:11.425Z",
+	"archived": false
+}`
+
+func TestCreateCompany(t *testing.T) {
+	c := hubSpot.NewClient("this-Is-A-Secret-!")
+
+	var gotRequest *http.Request
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			gotRequest = req
+			return newMockResponse(http.StatusCreated, companyJSON), nil
+		},
+	}
+
+	company, err := c.CreateCompany(hubSpot.NewCompanyInput(map[string]string{
+		"name":   "Marvel",
+		"domain": "marvel.com",
+	}))
+
+	assert.NoError(t, err, "expected empty error response")
+	assert.Equal(t, "4705054985", company.ID, "expected company id to have a value")
+	assert.Equal(t, time.Date(2020, time.October, 29, 12, 30, 11, 425000000, time.UTC), company.CreatedAt.UTC(),
+		"expected created at to be decoded")
+	assert.Equal(t, http.MethodPost, gotRequest.Method)
+	assert.Equal(t, "/crm/v3/objects/companies", gotRequest.URL.Path)
+}
+
+func TestReadCompany(t *testing.T) {
+	c := hubSpot.NewClient("fake-api-key")
+
+	tests := []struct {
+		name           string
+		id             string
+		idProperty     string
+		wantPath       string
+		wantStatusCode int
+		json           string
+	}{
+		{
+			name:           "by id",
+			id:             "4705054985",
+			wantPath:       "/crm/v3/objects/companies/4705054985",
+			wantStatusCode: http.StatusOK,
+			json:           companyJSON,
+		},
+		{
+			name:           "by domain",
+			id:             "marvel.com",
+			idProperty:     hubSpot.CompanyIDPropertyDomain,
+			wantPath:       "/crm/v3/objects/companies/marvel.com",
+			wantStatusCode: http.StatusOK,
+			json:           companyJSON,
+		},
+		{
+			name:           "not found",
+			id:             "123",
+			wantPath:       "/crm/v3/objects/companies/123",
+			wantStatusCode: http.StatusNotFound,
+			json:           "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotRequest *http.Request
+			c.HTTPClient = &MockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					gotRequest = req
+					return newMockResponse(tt.wantStatusCode, tt.json), nil
+				},
+			}
+
+			company, err := c.ReadCompany(tt.id, tt.idProperty, "name,domain")
+
+			assert.Equal(t, tt.wantPath, gotRequest.URL.Path)
+			assert.Equal(t, tt.idProperty, gotRequest.URL.Query().Get("idProperty"))
+			assert.Equal(t, "name,domain", gotRequest.URL.Query().Get("properties"))
+			if tt.wantStatusCode == http.StatusNotFound {
+				assert.True(t, errors.Is(err, hubSpot.ErrNotFound), "expected not found error")
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "marvel.com", company.Properties["domain"])
+			}
+		})
+	}
+}
+
+func TestArchiveCompany(t *testing.T) {
+	c := hubSpot.NewClient("fake-api-key")
+	c.HTTPClient = NewMockHTTPClient(http.StatusNoContent, "")
+
+	err := c.ArchiveCompany("4705054985")
+
+	assert.NoError(t, err, "ensure the function archived the company")
+}
+
+func TestCompanyRequiresID(t *testing.T) {
+	c := hubSpot.NewClient("fake-api-key")
+
+	calls := 0
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			return newMockResponse(http.StatusOK, `{"results": []}`), nil
+		},
+	}
+
+	_, err := c.ReadCompany("", "", "name")
+	assert.EqualError(t, err, "company identifier requires a value")
+
+	_, err = c.UpdateCompany(" ", hubSpot.NewCompanyInput(map[string]string{"name": "Marvel"}))
+	assert.EqualError(t, err, "company identifier requires a value")
+
+	err = c.ArchiveCompany("")
+	assert.EqualError(t, err, "company identifier requires a value")
+
+	assert.Equal(t, 0, calls, "expected no request to be sent")
+}
@@ -1,5 +1,14 @@
 package hubspot
 
+import (
+	"errors"
+	"regexp"
+	"time"
+)
+
+// ContactIDPropertyEmail reads a contact by its email address instead of its ID
+const ContactIDPropertyEmail = "email"
+
 // ContactInput handles a contact body representation from HubSpot
 type ContactInput struct {
 	Properties map[string]string `json:"properties"`
@@ -9,9 +18,64 @@ type ContactInput struct {
 type ContactOutput struct {
 	ID         string            `json:"id"`
 	Properties map[string]string `json:"properties"`
-	CreatedAt  string            `json:"created_at"`
-	Date       string            `json:"date"`
+	CreatedAt  time.Time         `json:"createdAt"`
+	UpdatedAt  time.Time         `json:"updatedAt"`
 	Archived   bool              `json:"archived"`
+	ArchivedAt time.Time         `json:"archivedAt"`
+
+	// Deprecated: HubSpot returns no date for a contact, so Date is always empty,
+	// use CreatedAt or UpdatedAt instead
+	Date string `json:"date,omitempty"`
+
+	PropertiesWithHistory map[string][]PropertyHistory  `json:"propertiesWithHistory,omitempty"`
+	Associations          map[string]ObjectAssociations `json:"associations,omitempty"`
+}
+
+// DateTimeProperty returns the value of a date or datetime property (e.g. createdate or
+// lastmodifieddate), the zero time when the contact has none
+func (c *ContactOutput) DateTimeProperty(name string) (time.Time, error) {
+	value := c.Properties[name]
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return ParseDateTime(value)
+}
+
+// UpsertContactOutput handles the result of UpsertContact
+type UpsertContactOutput struct {
+	Contact *ContactOutput
+	// Created is true when a new contact was created, false when an existing one was updated
+	Created bool
+}
+
+// MergeInput handles the two objects to merge, the merged object keeps the primary object's ID
+type MergeInput struct {
+	PrimaryObjectID string `json:"primaryObjectId"`
+	ObjectIDToMerge string `json:"objectIdToMerge"`
+}
+
+// GDPRDeleteInput handles the object to permanently delete
+type GDPRDeleteInput struct {
+	ObjectID   string `json:"objectId"`
+	IDProperty string `json:"idProperty,omitempty"`
+}
+
+// existingIDPattern matches the ID HubSpot names in a conflict error message,
+// e.g. "Contact already exists. Existing ID: 551"
+var existingIDPattern = regexp.MustCompile(`Existing ID: ?(\d+)`)
+
+// existingObjectID returns the ID of the existing object named in a conflict error
+func existingObjectID(err error) (string, bool) {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return "", false
+	}
+
+	match := existingIDPattern.FindStringSubmatch(apiErr.Message)
+	if match == nil {
+		return "", false
+	}
+	return match[1], true
 }
 
 // NewContactInput creates a new Contact Body representation
@@ -0,0 +1,37 @@
+package hubspot
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// dateTimeLayout is the ISO-8601 layout HubSpot uses for datetime values
+const dateTimeLayout = "2006-01-02T15:04:05.000Z"
+
+// dateLayout is the ISO-8601 layout HubSpot uses for date values
+const dateLayout = "2006-01-02"
+
+// FormatDateTime formats t as a HubSpot datetime property value
+func FormatDateTime(t time.Time) string {
+	return t.UTC().Format(dateTimeLayout)
+}
+
+// ParseDateTime parses a HubSpot date or datetime property value (e.g. createdate or
+// lastmodifieddate), given either in milliseconds since the epoch or as an ISO-8601
+// timestamp or date
+func ParseDateTime(value string) (time.Time, error) {
+	if ms, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(0, ms*int64(time.Millisecond)).UTC(), nil
+	}
+
+	if t, err := time.Parse(dateLayout, value); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid HubSpot datetime %q, err: %w", value, err)
+	}
+	return t, nil
+}
@@ -0,0 +1,52 @@
+package hubspot_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	hubSpot "github.com/teamexos/hubspot-api-go/hubspot"
+)
+
+func TestParseDateTime(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name:  "milliseconds",
+			value: "1597938474554",
+			want:  time.Date(2020, time.August, 20, 15, 47, 54, 554000000, time.UTC),
+		},
+		{
+			name:  "ISO-8601 datetime",
+			value: "2020-08-20T15:47:54.554Z",
+			want:  time.Date(2020, time.August, 20, 15, 47, 54, 554000000, time.UTC),
+		},
+		{
+			name:  "ISO-8601 date",
+			value: "2021-03-31",
+			want:  time.Date(2021, time.March, 31, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "invalid",
+			value:   "31/03/2021",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := hubSpot.ParseDateTime(tt.value)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "expected %s, got %s", tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package hubspot
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Deal object type and properties
+const (
+	ObjectTypeDeals = "deals"
+
+	DealPropertyName      = "dealname"
+	DealPropertyPipeline  = "pipeline"
+	DealPropertyDealStage = "dealstage"
+	DealPropertyAmount    = "amount"
+	DealPropertyCloseDate = "closedate"
+)
+
+// DefaultDealPipeline is the ID of the pipeline every HubSpot account starts with
+const DefaultDealPipeline = "default"
+
+// Deal stages of the DefaultDealPipeline
+const (
+	DealStageAppointmentScheduled  = "appointmentscheduled"
+	DealStageQualifiedToBuy        = "qualifiedtobuy"
+	DealStagePresentationScheduled = "presentationscheduled"
+	DealStageDecisionMakerBoughtIn = "decisionmakerboughtin"
+	DealStageContractSent          = "contractsent"
+	DealStageClosedWon             = "closedwon"
+	DealStageClosedLost            = "closedlost"
+)
+
+// DealInput handles a deal body representation from HubSpot
+type DealInput struct {
+	Properties map[string]string `json:"properties"`
+}
+
+// DealOutput handles a deal representation from HubSpot
+type DealOutput struct {
+	ID         string            `json:"id"`
+	Properties map[string]string `json:"properties"`
+	CreatedAt  time.Time         `json:"createdAt"`
+	UpdatedAt  time.Time         `json:"updatedAt"`
+	Archived   bool              `json:"archived"`
+	ArchivedAt time.Time         `json:"archivedAt"`
+}
+
+// NewDealInput creates a new Deal Body representation
+func NewDealInput(properties map[string]string) *DealInput {
+	if properties == nil {
+		properties = map[string]string{}
+	}
+	return &DealInput{
+		Properties: properties,
+	}
+}
+
+// SetName sets the deal name
+func (d *DealInput) SetName(name string) *DealInput {
+	return d.set(DealPropertyName, name)
+}
+
+// SetPipeline sets the ID of the pipeline the deal belongs to
+func (d *DealInput) SetPipeline(pipelineID string) *DealInput {
+	return d.set(DealPropertyPipeline, pipelineID)
+}
+
+// SetDealStage sets the ID of the deal stage within the deal pipeline
+func (d *DealInput) SetDealStage(stageID string) *DealInput {
+	return d.set(DealPropertyDealStage, stageID)
+}
+
+// SetAmount sets the deal amount
+func (d *DealInput) SetAmount(amount float64) *DealInput {
+	return d.set(DealPropertyAmount, strconv.FormatFloat(amount, 'f', -1, 64))
+}
+
+// SetCloseDate sets the date the deal is expected to close, or closed
+func (d *DealInput) SetCloseDate(closeDate time.Time) *DealInput {
+	return d.set(DealPropertyCloseDate, FormatDateTime(closeDate))
+}
+
+// set sets a property, creating the properties map when needed
+func (d *DealInput) set(property string, value string) *DealInput {
+	if d.Properties == nil {
+		d.Properties = map[string]string{}
+	}
+	d.Properties[property] = value
+	return d
+}
+
+// Pipeline returns the ID of the pipeline the deal belongs to
+func (d *DealOutput) Pipeline() string {
+	return d.Properties[DealPropertyPipeline]
+}
+
+// DealStage returns the ID of the deal stage within the deal pipeline
+func (d *DealOutput) DealStage() string {
+	return d.Properties[DealPropertyDealStage]
+}
+
+// Amount returns the deal amount, zero when the deal has none
+func (d *DealOutput) Amount() (float64, error) {
+	value := d.Properties[DealPropertyAmount]
+	if value == "" {
+		return 0, nil
+	}
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid deal amount %q, err: %w", value, err)
+	}
+	return amount, nil
+}
+
+// CloseDate returns the deal close date, the zero time when the deal has none
+func (d *DealOutput) CloseDate() (time.Time, error) {
+	value := d.Properties[DealPropertyCloseDate]
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return ParseDateTime(value)
+}
+
+// CreateDeal creates a new Deal in HubSpot
+func (c *Client) CreateDeal(dealInput *DealInput) (*DealOutput, error) {
+	return c.CreateDealWithContext(context.Background(), dealInput)
+}
+
+// CreateDealWithContext creates a new Deal in HubSpot using the given context
+func (c *Client) CreateDealWithContext(ctx context.Context, dealInput *DealInput) (*DealOutput, error) {
+	apiURL := c.buildURL(c.objectPath(ObjectTypeDeals, ""), nil)
+
+	var dealOutput DealOutput
+	if err := c.do(ctx, http.MethodPost, apiURL, dealInput, http.StatusCreated, &dealOutput); err != nil {
+		return nil, err
+	}
+
+	return &dealOutput, nil
+}
+
+// ReadDeal gets a Deal in HubSpot
+// properties is a comma separated string (no spaces!) of the properties (dealname,amount,..) to be returned in the response
+func (c *Client) ReadDeal(dealID string, properties string) (*DealOutput, error) {
+	return c.ReadDealWithContext(context.Background(), dealID, properties)
+}
+
+// ReadDealWithContext gets a Deal in HubSpot using the given context
+func (c *Client) ReadDealWithContext(ctx context.Context, dealID string, properties string) (*DealOutput, error) {
+	if err := checkObjectID("deal", dealID); err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	if properties != "" {
+		query.Set("properties", properties)
+	}
+	apiURL := c.buildURL(c.objectPath(ObjectTypeDeals, dealID), query)
+
+	var dealOutput DealOutput
+	if err := c.do(ctx, http.MethodGet, apiURL, nil, http.StatusOK, &dealOutput); err != nil {
+		return nil, err
+	}
+
+	return &dealOutput, nil
+}
+
+// UpdateDeal updates a Deal in HubSpot
+func (c *Client) UpdateDeal(dealID string, dealInput *DealInput) (*DealOutput, error) {
+	return c.UpdateDealWithContext(context.Background(), dealID, dealInput)
+}
+
+// UpdateDealWithContext updates a Deal in HubSpot using the given context
+func (c *Client) UpdateDealWithContext(ctx context.Context, dealID string, dealInput *DealInput) (*DealOutput, error) {
+	if err := checkObjectID("deal", dealID); err != nil {
+		return nil, err
+	}
+
+	apiURL := c.buildURL(c.objectPath(ObjectTypeDeals, dealID), nil)
+
+	var dealOutput DealOutput
+	if err := c.do(ctx, http.MethodPatch, apiURL, dealInput, http.StatusOK, &dealOutput); err != nil {
+		return nil, err
+	}
+
+	return &dealOutput, nil
+}
+
+// ArchiveDeal moves a Deal in HubSpot to the recycling bin
+func (c *Client) ArchiveDeal(dealID string) error {
+	return c.ArchiveDealWithContext(context.Background(), dealID)
+}
+
+// ArchiveDealWithContext moves a Deal in HubSpot to the recycling bin using the given context
+func (c *Client) ArchiveDealWithContext(ctx context.Context, dealID string) error {
+	if err := checkObjectID("deal", dealID); err != nil {
+		return err
+	}
+
+	apiURL := c.buildURL(c.objectPath(ObjectTypeDeals, dealID), nil)
+	return c.do(ctx, http.MethodDelete, apiURL, nil, http.StatusNoContent, nil)
+}
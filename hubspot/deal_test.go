@@ -0,0 +1,111 @@
+package hubspot_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	hubSpot "github.com/teamexos/hubspot-api-go/hubspot"
+)
+
+func TestCreateDeal(t *testing.T) {
+	c := hubSpot.NewClient("this-Is-A-Secret-!")
+
+	var gotBody hubSpot.DealInput
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			body, _ := ioutil.ReadAll(req.Body)
+			assert.NoError(t, json.Unmarshal(body, &gotBody))
+			return newMockResponse(http.StatusCreated, `{
+				"id": "5512",
+				"properties": {
+					"amount": "1500.5",
+					"closedate": "2021-03-31T00:00:00Z",
+					"dealname": "EXOS Perform",
+					"dealstage": "contractsent",
+					"pipeline": "default"
+				},
+				"createdAt": "2021-01-12T15:47:54.554Z",
+				"updatedAt": "2021-01-12T15:47:54.554Z",
+				"archived": false
+			}`), nil
+		},
+	}
+
+	closeDate := time.Date(2021, time.March, 31, 0, 0, 0, 0, time.UTC)
+	dealInput := hubSpot.NewDealInput(nil).
+		SetName("EXOS Perform").
+		SetPipeline(hubSpot.DefaultDealPipeline).
+		SetDealStage(hubSpot.DealStageContractSent).
+		SetAmount(1500.5).
+		SetCloseDate(closeDate)
+
+	deal, err := c.CreateDeal(dealInput)
+	assert.NoError(t, err, "expected empty error response")
+
+	assert.Equal(t, "default", gotBody.Properties["pipeline"])
+	assert.Equal(t, "contractsent", gotBody.Properties["dealstage"])
+	assert.Equal(t, "1500.5", gotBody.Properties["amount"])
+	assert.Equal(t, "2021-03-31T00:00:00.000Z", gotBody.Properties["closedate"])
+
+	assert.Equal(t, "5512", deal.ID)
+	assert.Equal(t, hubSpot.DefaultDealPipeline, deal.Pipeline())
+	assert.Equal(t, hubSpot.DealStageContractSent, deal.DealStage())
+
+	amount, err := deal.Amount()
+	assert.NoError(t, err)
+	assert.Equal(t, 1500.5, amount)
+
+	gotCloseDate, err := deal.CloseDate()
+	assert.NoError(t, err)
+	assert.True(t, closeDate.Equal(gotCloseDate), "expected the close date to round trip")
+}
+
+func TestDealCloseDateMilliseconds(t *testing.T) {
+	deal := &hubSpot.DealOutput{
+		Properties: map[string]string{
+			"closedate": "1617148800000",
+		},
+	}
+
+	closeDate, err := deal.CloseDate()
+
+	assert.NoError(t, err)
+	assert.True(t, time.Date(2021, time.March, 31, 0, 0, 0, 0, time.UTC).Equal(closeDate))
+}
+
+func TestDealAssociationInputs(t *testing.T) {
+	toContact := hubSpot.NewSingleDealToContactAssociationInput("5512", "3051")
+	toCompany := hubSpot.NewSingleDealToCompanyAssociationInput("5512", "4705054985")
+
+	assert.Equal(t, hubSpot.AssociationDealToContact, toContact.Inputs[0].AssociationType)
+	assert.Equal(t, "3051", toContact.Inputs[0].To.ID)
+	assert.Equal(t, hubSpot.AssociationDealToCompany, toCompany.Inputs[0].AssociationType)
+	assert.Equal(t, "5512", toCompany.Inputs[0].From.ID)
+}
+
+func TestDealRequiresID(t *testing.T) {
+	c := hubSpot.NewClient("fake-api-key")
+
+	calls := 0
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			return newMockResponse(http.StatusOK, `{"results": []}`), nil
+		},
+	}
+
+	_, err := c.ReadDeal("", "dealname")
+	assert.EqualError(t, err, "deal identifier requires a value")
+
+	_, err = c.UpdateDeal(" ", hubSpot.NewDealInput(nil).SetName("EXOS Perform"))
+	assert.EqualError(t, err, "deal identifier requires a value")
+
+	err = c.ArchiveDeal("")
+	assert.EqualError(t, err, "deal identifier requires a value")
+
+	assert.Equal(t, 0, calls, "expected no request to be sent")
+}
@@ -0,0 +1,132 @@
+package hubspot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Sentinel errors matched by an *APIError with errors.Is
+var (
+	ErrBadRequest   = errors.New("bad request")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrRateLimited  = errors.New("rate limited")
+)
+
+// HubSpot error categories
+const (
+	CategoryConflict              = "CONFLICT"
+	CategoryInvalidAuthentication = "INVALID_AUTHENTICATION"
+	CategoryObjectNotFound        = "OBJECT_NOT_FOUND"
+	CategoryRateLimits            = "RATE_LIMITS"
+	CategoryValidationError       = "VALIDATION_ERROR"
+)
+
+// APIError handles the error structure returned by HubSpot API. Batch and association
+// endpoints answering with a multi-status response list every failed input in Errors.
+type APIError struct {
+	Category      string              `json:"category"`
+	SubCategory   string              `json:"subCategory"`
+	CorrelationID string              `json:"correlationId"`
+	Context       map[string][]string `json:"context"`
+	Links         map[string]string   `json:"links"`
+	Message       string              `json:"message"`
+	Status        string              `json:"status"`
+	StatusCode    int                 `json:"-"`
+	NumErrors     int                 `json:"numErrors"`
+	Errors        []APIError          `json:"errors"`
+}
+
+// ErrorResponse handles the error structure returned by HubSpot API
+//
+// Deprecated: methods return an error, use errors.As with an *APIError instead.
+type ErrorResponse = APIError
+
+// AssociationErrorResponse handles the HubSpot error when associating two objects
+//
+// Deprecated: methods return an error, use errors.As with an *APIError instead.
+type AssociationErrorResponse = APIError
+
+// newAPIError builds an *APIError from an unsuccessful HubSpot response
+func newAPIError(r *Response) *APIError {
+	apiError := &APIError{}
+	if len(r.Body) > 0 {
+		// an undecodable body still leaves a usable error built from the status code
+		_ = json.Unmarshal(r.Body, apiError)
+	}
+
+	apiError.StatusCode = r.StatusCode
+	if apiError.Status == "" {
+		apiError.Status = "error"
+	}
+	if apiError.Message == "" && len(apiError.Errors) == 0 {
+		apiError.Message = http.StatusText(r.StatusCode)
+	}
+	return apiError
+}
+
+func (e *APIError) Error() string {
+	message := e.Message
+	if message == "" && len(e.Errors) > 0 {
+		message = e.Errors[0].Message
+		if len(e.Errors) > 1 {
+			message = fmt.Sprintf("%s (and %d more errors)", message, len(e.Errors)-1)
+		}
+	}
+
+	category := e.Category
+	if category == "" && len(e.Errors) > 0 {
+		category = e.Errors[0].Category
+	}
+
+	if category == "" {
+		return fmt.Sprintf("HubSpot API error %d: %s", e.StatusCode, message)
+	}
+	return fmt.Sprintf("HubSpot API error %d %s: %s", e.StatusCode, category, message)
+}
+
+// Is reports whether the error matches one of the sentinel errors, by status code,
+// by category, or by the category of every error in a multi-status response
+func (e *APIError) Is(target error) bool {
+	switch {
+	case e.StatusCode == http.StatusBadRequest:
+		return target == ErrBadRequest
+	case e.StatusCode == http.StatusUnauthorized || e.Category == CategoryInvalidAuthentication:
+		return target == ErrUnauthorized
+	case e.StatusCode == http.StatusForbidden:
+		return target == ErrForbidden
+	case e.StatusCode == http.StatusNotFound || e.Category == CategoryObjectNotFound:
+		return target == ErrNotFound
+	case e.StatusCode == http.StatusConflict || e.Category == CategoryConflict:
+		return target == ErrConflict
+	case e.StatusCode == http.StatusTooManyRequests || e.Category == CategoryRateLimits:
+		return target == ErrRateLimited
+	}
+
+	if len(e.Errors) == 0 {
+		return false
+	}
+	for i := range e.Errors {
+		if !e.Errors[i].Is(target) {
+			return false
+		}
+	}
+	return true
+}
+
+// transportError handles a request that failed before HubSpot returned a response
+type transportError struct {
+	err error
+}
+
+func (e *transportError) Error() string {
+	return fmt.Sprintf("request execution failed: %v", e.err)
+}
+
+func (e *transportError) Unwrap() error {
+	return e.err
+}
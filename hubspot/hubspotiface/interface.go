@@ -1,16 +1,77 @@
 package hubspotiface
 
 import (
+	"context"
+
 	"github.com/teamexos/hubspot-api-go/hubspot"
 )
 
 // HubSpotClient is an interface for the Hubspot Client
 type HubSpotClient interface {
-	CreateAssociation(association *hubspot.AssociationInput, from string, to string) (*hubspot.AssociationResults, hubspot.AssociationErrorResponse)
-	CreateContact(contactInput *hubspot.ContactInput) (*hubspot.ContactOutput, hubspot.ErrorResponse)
-	UpdateContact(contactID string, contactInput *hubspot.ContactInput) (*hubspot.ContactOutput, hubspot.ErrorResponse)
-	ReadContact(email string, properties string) (*hubspot.ContactOutput, hubspot.ErrorResponse)
-	DeleteContact(contactID string) hubspot.ErrorResponse
+	CreateAssociation(association *hubspot.AssociationInput, from string, to string) (*hubspot.AssociationResults, error)
+	CreateAssociationWithContext(ctx context.Context, association *hubspot.AssociationInput, from string, to string) (*hubspot.AssociationResults, error)
+	CreateCompany(companyInput *hubspot.CompanyInput) (*hubspot.CompanyOutput, error)
+	CreateCompanyWithContext(ctx context.Context, companyInput *hubspot.CompanyInput) (*hubspot.CompanyOutput, error)
+	ReadCompany(id string, idProperty string, properties string) (*hubspot.CompanyOutput, error)
+	ReadCompanyWithContext(ctx context.Context, id string, idProperty string, properties string) (*hubspot.CompanyOutput, error)
+	UpdateCompany(companyID string, companyInput *hubspot.CompanyInput) (*hubspot.CompanyOutput, error)
+	UpdateCompanyWithContext(ctx context.Context, companyID string, companyInput *hubspot.CompanyInput) (*hubspot.CompanyOutput, error)
+	ArchiveCompany(companyID string) error
+	ArchiveCompanyWithContext(ctx context.Context, companyID string) error
+	BatchCreateContacts(inputs []*hubspot.ContactInput) ([]hubspot.BatchContactResult, error)
+	BatchCreateContactsWithContext(ctx context.Context, inputs []*hubspot.ContactInput) ([]hubspot.BatchContactResult, error)
+	BatchReadContacts(ids []string, opts *hubspot.BatchReadOptions) ([]hubspot.BatchContactResult, error)
+	BatchReadContactsWithContext(ctx context.Context, ids []string, opts *hubspot.BatchReadOptions) ([]hubspot.BatchContactResult, error)
+	BatchUpdateContacts(inputs []hubspot.BatchUpdateInput) ([]hubspot.BatchContactResult, error)
+	BatchUpdateContactsWithContext(ctx context.Context, inputs []hubspot.BatchUpdateInput) ([]hubspot.BatchContactResult, error)
+	BatchArchiveContacts(ids []string) ([]hubspot.BatchContactResult, error)
+	BatchArchiveContactsWithContext(ctx context.Context, ids []string) ([]hubspot.BatchContactResult, error)
+	CreateDeal(dealInput *hubspot.DealInput) (*hubspot.DealOutput, error)
+	CreateDealWithContext(ctx context.Context, dealInput *hubspot.DealInput) (*hubspot.DealOutput, error)
+	ReadDeal(dealID string, properties string) (*hubspot.DealOutput, error)
+	ReadDealWithContext(ctx context.Context, dealID string, properties string) (*hubspot.DealOutput, error)
+	UpdateDeal(dealID string, dealInput *hubspot.DealInput) (*hubspot.DealOutput, error)
+	UpdateDealWithContext(ctx context.Context, dealID string, dealInput *hubspot.DealInput) (*hubspot.DealOutput, error)
+	ArchiveDeal(dealID string) error
+	ArchiveDealWithContext(ctx context.Context, dealID string) error
+	CreateContact(contactInput *hubspot.ContactInput) (*hubspot.ContactOutput, error)
+	CreateContactWithContext(ctx context.Context, contactInput *hubspot.ContactInput) (*hubspot.ContactOutput, error)
+	UpdateContact(contactID string, contactInput *hubspot.ContactInput) (*hubspot.ContactOutput, error)
+	UpdateContactWithContext(ctx context.Context, contactID string, contactInput *hubspot.ContactInput) (*hubspot.ContactOutput, error)
+	ReadContact(email string, properties string) (*hubspot.ContactOutput, error)
+	ReadContactWithContext(ctx context.Context, email string, properties string) (*hubspot.ContactOutput, error)
+	ReadContactWithOptions(email string, opts *hubspot.ReadOptions) (*hubspot.ContactOutput, error)
+	ReadContactWithOptionsWithContext(ctx context.Context, email string, opts *hubspot.ReadOptions) (*hubspot.ContactOutput, error)
+	ReadContactByID(contactID string, opts *hubspot.ReadOptions) (*hubspot.ContactOutput, error)
+	ReadContactByIDWithContext(ctx context.Context, contactID string, opts *hubspot.ReadOptions) (*hubspot.ContactOutput, error)
+	ReadContactByIDProperty(idProperty string, value string, opts *hubspot.ReadOptions) (*hubspot.ContactOutput, error)
+	ReadContactByIDPropertyWithContext(ctx context.Context, idProperty string, value string, opts *hubspot.ReadOptions) (*hubspot.ContactOutput, error)
+	ListContacts(opts *hubspot.ListOptions) *hubspot.ContactIterator
+	ListContactsWithContext(ctx context.Context, opts *hubspot.ListOptions) *hubspot.ContactIterator
+	UpsertContact(idProperty string, contactInput *hubspot.ContactInput) (*hubspot.UpsertContactOutput, error)
+	UpsertContactWithContext(ctx context.Context, idProperty string, contactInput *hubspot.ContactInput) (*hubspot.UpsertContactOutput, error)
+	MergeContacts(primaryContactID string, secondaryContactID string) (*hubspot.ContactOutput, error)
+	MergeContactsWithContext(ctx context.Context, primaryContactID string, secondaryContactID string) (*hubspot.ContactOutput, error)
+	DeleteContact(contactID string) error
+	DeleteContactWithContext(ctx context.Context, contactID string) error
+	RecreateArchivedContact(contactID string, properties []string) (*hubspot.ContactOutput, error)
+	RecreateArchivedContactWithContext(ctx context.Context, contactID string, properties []string) (*hubspot.ContactOutput, error)
+	GDPRDeleteContact(id string, idProperty string) error
+	GDPRDeleteContactWithContext(ctx context.Context, id string, idProperty string) error
+	Objects(objectType string) *hubspot.ObjectsService
+	Properties(objectType string) *hubspot.PropertiesService
+	Associations(fromObjectType string, toObjectType string) *hubspot.AssociationsService
+	Schema() *hubspot.SchemaService
+	Search(objectType string, search *hubspot.SearchRequest) (*hubspot.SearchResults, error)
+	SearchWithContext(ctx context.Context, objectType string, search *hubspot.SearchRequest) (*hubspot.SearchResults, error)
+	CreateTicket(ticketInput *hubspot.TicketInput) (*hubspot.TicketOutput, error)
+	CreateTicketWithContext(ctx context.Context, ticketInput *hubspot.TicketInput) (*hubspot.TicketOutput, error)
+	ReadTicket(ticketID string, properties string) (*hubspot.TicketOutput, error)
+	ReadTicketWithContext(ctx context.Context, ticketID string, properties string) (*hubspot.TicketOutput, error)
+	UpdateTicket(ticketID string, ticketInput *hubspot.TicketInput) (*hubspot.TicketOutput, error)
+	UpdateTicketWithContext(ctx context.Context, ticketID string, ticketInput *hubspot.TicketInput) (*hubspot.TicketOutput, error)
+	ArchiveTicket(ticketID string) error
+	ArchiveTicketWithContext(ctx context.Context, ticketID string) error
 }
 
 // make sure hubspot.Client type satisfies the HubSpotClient interface
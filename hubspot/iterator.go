@@ -0,0 +1,103 @@
+package hubspot
+
+import (
+	"context"
+	"net/http"
+)
+
+// ListPageLimit is the maximum page size HubSpot allows when listing CRM objects
+const ListPageLimit = 100
+
+// contactPage handles a page of contacts
+type contactPage struct {
+	Results []ContactOutput `json:"results"`
+	Paging  *Paging         `json:"paging"`
+}
+
+// ContactIterator streams contacts page by page, following HubSpot's paging cursors
+//
+//	it := client.ListContacts(nil)
+//	for it.Next() {
+//		contact := it.Contact()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type ContactIterator struct {
+	ctx    context.Context
+	client *Client
+	opts   ListOptions
+
+	page    []ContactOutput
+	index   int
+	contact *ContactOutput
+	done    bool
+	err     error
+}
+
+// ListContacts returns an iterator over every Contact in HubSpot, starting at opts.After
+func (c *Client) ListContacts(opts *ListOptions) *ContactIterator {
+	return c.ListContactsWithContext(context.Background(), opts)
+}
+
+// ListContactsWithContext returns an iterator over every Contact in HubSpot using the given context
+func (c *Client) ListContactsWithContext(ctx context.Context, opts *ListOptions) *ContactIterator {
+	it := &ContactIterator{
+		ctx:    ctx,
+		client: c,
+	}
+	if opts != nil {
+		it.opts = *opts
+	}
+	if it.opts.Limit <= 0 {
+		it.opts.Limit = ListPageLimit
+	}
+	return it
+}
+
+// Next advances to the next contact, requesting the next page when needed. It returns
+// false when there are no more contacts or a request failed, see Err.
+func (it *ContactIterator) Next() bool {
+	for it.index >= len(it.page) {
+		if it.done || it.err != nil {
+			it.contact = nil
+			return false
+		}
+		it.fetch()
+	}
+
+	it.contact = &it.page[it.index]
+	it.index++
+	return true
+}
+
+// Contact returns the current contact
+func (it *ContactIterator) Contact() *ContactOutput {
+	return it.contact
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *ContactIterator) Err() error {
+	return it.err
+}
+
+// After returns the paging cursor of the next page, to resume an interrupted iteration
+func (it *ContactIterator) After() string {
+	return it.opts.After
+}
+
+// fetch requests the next page of contacts
+func (it *ContactIterator) fetch() {
+	apiURL := it.client.buildURL(it.client.objectPath(ObjectTypeContacts, ""), it.opts.query())
+
+	var page contactPage
+	if err := it.client.do(it.ctx, http.MethodGet, apiURL, nil, http.StatusOK, &page); err != nil {
+		it.err = err
+		return
+	}
+
+	it.page = page.Results
+	it.index = 0
+	it.opts.After = page.Paging.NextAfter()
+	it.done = it.opts.After == ""
+}
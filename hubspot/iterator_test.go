@@ -0,0 +1,80 @@
+package hubspot_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	hubSpot "github.com/teamexos/hubspot-api-go/hubspot"
+)
+
+func TestListContacts(t *testing.T) {
+	c := hubSpot.NewClient("fake-api-key")
+
+	pages := map[string]string{
+		"": `{
+			"results": [{"id": "1"}, {"id": "2"}],
+			"paging": {"next": {"after": "3"}}
+		}`,
+		"3": `{
+			"results": [],
+			"paging": {"next": {"after": "5"}}
+		}`,
+		"5": `{
+			"results": [{"id": "5"}]
+		}`,
+	}
+
+	var gotAfters []string
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			after := req.URL.Query().Get("after")
+			gotAfters = append(gotAfters, after)
+			assert.Equal(t, "100", req.URL.Query().Get("limit"), "expected the largest page size by default")
+			return newMockResponse(http.StatusOK, pages[after]), nil
+		},
+	}
+
+	it := c.ListContacts(&hubSpot.ListOptions{Properties: []string{"email"}})
+
+	var gotIDs []string
+	for it.Next() {
+		gotIDs = append(gotIDs, it.Contact().ID)
+	}
+
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []string{"1", "2", "5"}, gotIDs)
+	assert.Equal(t, []string{"", "3", "5"}, gotAfters, "expected every page to be requested once")
+	assert.False(t, it.Next(), "expected the iterator to stay exhausted")
+}
+
+func TestListContactsError(t *testing.T) {
+	c := hubSpot.NewClient("fake-api-key")
+
+	calls := 0
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls > 1 {
+				return newMockResponse(http.StatusUnauthorized, `{"status": "error", "category": "INVALID_AUTHENTICATION"}`), nil
+			}
+			return newMockResponse(http.StatusOK, fmt.Sprintf(`{
+				"results": [{"id": "%d"}],
+				"paging": {"next": {"after": "2"}}
+			}`, calls)), nil
+		},
+	}
+
+	it := c.ListContacts(nil)
+
+	count := 0
+	for it.Next() {
+		count++
+	}
+
+	assert.Equal(t, 1, count)
+	assert.True(t, errors.Is(it.Err(), hubSpot.ErrUnauthorized), "expected the request error")
+	assert.Equal(t, "2", it.After(), "expected the cursor of the failed page to resume from")
+}
@@ -0,0 +1,343 @@
+package hubspot
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// multiSelectSeparator separates the values of a multiple checkboxes property
+const multiSelectSeparator = ";"
+
+// timeType is the reflected type of time.Time
+var timeType = reflect.TypeOf(time.Time{})
+
+// propertyField handles a struct field mapped to a HubSpot property with a hubspot tag
+type propertyField struct {
+	name      string
+	index     []int
+	omitEmpty bool
+	date      bool
+}
+
+// MarshalProperties converts a struct to HubSpot property values. Fields are mapped to
+// properties with a hubspot tag, e.g. `hubspot:"firstname"`, and untagged fields are skipped.
+//
+// Supported field types are strings and string based enumeration types, bools, ints, uints,
+// floats, time.Time, string slices for multiple checkboxes properties joined by ";", and
+// pointers to any of them. Times are formatted as ISO-8601 datetimes, or with the date option,
+// e.g. `hubspot:"birthday,date"`, as their calendar date in the time's location. Nil pointers
+// are skipped, and zero values are skipped with the omitempty option, otherwise they clear
+// the property.
+func MarshalProperties(v interface{}) (map[string]string, error) {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil, errors.New("properties require a non-nil struct")
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("properties require a struct, got %s", value.Type())
+	}
+
+	fields, err := propertyFields(value.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	properties := map[string]string{}
+	for _, field := range fields {
+		fieldValue := value.FieldByIndex(field.index)
+		if fieldValue.Kind() == reflect.Ptr {
+			if fieldValue.IsNil() {
+				continue
+			}
+			fieldValue = fieldValue.Elem()
+		}
+		if field.omitEmpty && isZeroProperty(fieldValue) {
+			continue
+		}
+
+		property, err := formatProperty(fieldValue, field.date)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for property %s, err: %w", field.name, err)
+		}
+		properties[field.name] = property
+	}
+
+	return properties, nil
+}
+
+// UnmarshalProperties converts HubSpot property values to the struct v points to, using the
+// mapping of MarshalProperties. Properties without a value set their field to its zero value.
+func UnmarshalProperties(properties map[string]string, v interface{}) error {
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("properties require a non-nil pointer to a struct, got %T", v)
+	}
+	value = value.Elem()
+
+	fields, err := propertyFields(value.Type())
+	if err != nil {
+		return err
+	}
+
+	for _, field := range fields {
+		fieldValue := value.FieldByIndex(field.index)
+		property := properties[field.name]
+
+		if property == "" {
+			fieldValue.Set(reflect.Zero(fieldValue.Type()))
+			continue
+		}
+		if fieldValue.Kind() == reflect.Ptr {
+			fieldValue.Set(reflect.New(fieldValue.Type().Elem()))
+			fieldValue = fieldValue.Elem()
+		}
+
+		if err := parseProperty(property, fieldValue); err != nil {
+			return fmt.Errorf("invalid value %q for property %s, err: %w", property, field.name, err)
+		}
+	}
+
+	return nil
+}
+
+// PropertyNames returns the names of the properties a struct is mapped to, e.g. to
+// request them in ReadOptions.Properties
+func PropertyNames(v interface{}) ([]string, error) {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("properties require a struct, got %T", v)
+	}
+
+	fields, err := propertyFields(t)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = field.name
+	}
+	return names, nil
+}
+
+// NewContactInputFromStruct creates a new Contact Body representation from a struct
+// mapped to properties with hubspot tags, see MarshalProperties
+func NewContactInputFromStruct(v interface{}) (*ContactInput, error) {
+	properties, err := MarshalProperties(v)
+	if err != nil {
+		return nil, err
+	}
+	return NewContactInput(properties), nil
+}
+
+// Unmarshal converts the contact properties to the struct v points to, see UnmarshalProperties
+func (c *ContactOutput) Unmarshal(v interface{}) error {
+	return UnmarshalProperties(c.Properties, v)
+}
+
+// propertyFields returns the tagged fields of a struct type, including those of embedded structs
+func propertyFields(t reflect.Type) ([]propertyField, error) {
+	var fields []propertyField
+
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+		tag, tagged := structField.Tag.Lookup("hubspot")
+
+		if !tagged && structField.Anonymous && structField.Type.Kind() == reflect.Struct {
+			embedded, err := propertyFields(structField.Type)
+			if err != nil {
+				return nil, err
+			}
+			for _, field := range embedded {
+				field.index = append([]int{i}, field.index...)
+				fields = append(fields, field)
+			}
+			continue
+		}
+		if !tagged || tag == "-" || structField.PkgPath != "" {
+			continue
+		}
+
+		options := strings.Split(tag, ",")
+		field := propertyField{name: options[0], index: []int{i}}
+		if field.name == "" {
+			return nil, fmt.Errorf("field %s requires a property name in its hubspot tag", structField.Name)
+		}
+		for _, option := range options[1:] {
+			switch option {
+			case "omitempty":
+				field.omitEmpty = true
+			case "date":
+				field.date = true
+			default:
+				return nil, fmt.Errorf("field %s has unknown hubspot tag option %q", structField.Name, option)
+			}
+		}
+
+		if err := checkPropertyType(structField.Type); err != nil {
+			return nil, fmt.Errorf("field %s, err: %w", structField.Name, err)
+		}
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// checkPropertyType makes sure a field type can be converted to a property value
+func checkPropertyType(t reflect.Type) error {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return nil
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.String {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported property type %s", t)
+}
+
+// isZeroProperty reports whether a field holds its zero value, or an empty multi-select
+func isZeroProperty(v reflect.Value) bool {
+	if v.Kind() == reflect.Slice {
+		return v.Len() == 0
+	}
+	if v.Type() == timeType {
+		return v.Interface().(time.Time).IsZero()
+	}
+	return v.IsZero()
+}
+
+// formatProperty formats a field value in HubSpot's wire format
+func formatProperty(v reflect.Value, date bool) (string, error) {
+	if v.Type() == timeType {
+		t := v.Interface().(time.Time)
+		switch {
+		case t.IsZero():
+			return "", nil
+		case date:
+			// the calendar date in the time's own location, converting to UTC would shift
+			// a local midnight east of UTC to the previous day
+			return t.Format(dateLayout), nil
+		default:
+			return FormatDateTime(t), nil
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return "", fmt.Errorf("unsupported number %v", f)
+		}
+		return strconv.FormatFloat(f, 'f', -1, v.Type().Bits()), nil
+	case reflect.Slice:
+		values := make([]string, v.Len())
+		for i := range values {
+			values[i] = v.Index(i).String()
+		}
+		return strings.Join(values, multiSelectSeparator), nil
+	}
+	return "", fmt.Errorf("unsupported property type %s", v.Type())
+}
+
+// parseProperty parses a property value in HubSpot's wire format into a field
+func parseProperty(property string, v reflect.Value) error {
+	if v.Type() == timeType {
+		t, err := ParseDateTime(property)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(property)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(property)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := parseWholeNumber(property)
+		if err != nil {
+			return err
+		}
+		if v.OverflowInt(n) {
+			return fmt.Errorf("number overflows %s", v.Type())
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := parseWholeNumber(property)
+		if err != nil {
+			return err
+		}
+		if n < 0 || v.OverflowUint(uint64(n)) {
+			return fmt.Errorf("number overflows %s", v.Type())
+		}
+		v.SetUint(uint64(n))
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(property, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		values := strings.Split(property, multiSelectSeparator)
+		slice := reflect.MakeSlice(v.Type(), len(values), len(values))
+		for i, value := range values {
+			slice.Index(i).SetString(value)
+		}
+		v.Set(slice)
+	default:
+		return fmt.Errorf("unsupported property type %s", v.Type())
+	}
+	return nil
+}
+
+// parseWholeNumber parses an integer, HubSpot number properties may hold it as e.g. "12.0"
+func parseWholeNumber(property string) (int64, error) {
+	if n, err := strconv.ParseInt(property, 10, 64); err == nil {
+		return n, nil
+	}
+
+	f, err := strconv.ParseFloat(property, 64)
+	if err != nil {
+		return 0, err
+	}
+	if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, fmt.Errorf("%s is not a whole number", property)
+	}
+	return int64(f), nil
+}
@@ -0,0 +1,107 @@
+package hubspot_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	hubSpot "github.com/teamexos/hubspot-api-go/hubspot"
+)
+
+type lifecycleStage string
+
+type testContact struct {
+	FirstName       string         `hubspot:"firstname"`
+	Email           string         `hubspot:"email,omitempty"`
+	Verified        bool           `hubspot:"exos_perform_account_verified"`
+	Sessions        int            `hubspot:"exos_sessions"`
+	Score           float64        `hubspot:"exos_score,omitempty"`
+	Birthday        time.Time      `hubspot:"date_of_birth,date"`
+	LastSession     *time.Time     `hubspot:"exos_last_session"`
+	LifecycleStage  lifecycleStage `hubspot:"lifecyclestage,omitempty"`
+	Sports          []string       `hubspot:"exos_sports,omitempty"`
+	Notes           string
+	IgnoredProperty string `hubspot:"-"`
+}
+
+func TestMarshalProperties(t *testing.T) {
+	lastSession := time.Date(2021, time.January, 12, 15, 47, 54, 554000000, time.UTC)
+	contact := testContact{
+		FirstName:      "Peter",
+		Verified:       true,
+		Sessions:       12,
+		Birthday:       time.Date(2001, time.August, 10, 0, 0, 0, 0, time.UTC),
+		LastSession:    &lastSession,
+		LifecycleStage: "customer",
+		Sports:         []string{"football", "tennis"},
+		Notes:          "not a property",
+	}
+
+	input, err := hubSpot.NewContactInputFromStruct(&contact)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"firstname":                     "Peter",
+		"exos_perform_account_verified": "true",
+		"exos_sessions":                 "12",
+		"date_of_birth":                 "2001-08-10",
+		"exos_last_session":             "2021-01-12T15:47:54.554Z",
+		"lifecyclestage":                "customer",
+		"exos_sports":                   "football;tennis",
+	}, input.Properties)
+
+	contact.Birthday = time.Date(2001, time.August, 10, 0, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	input, err = hubSpot.NewContactInputFromStruct(&contact)
+	assert.NoError(t, err)
+	assert.Equal(t, "2001-08-10", input.Properties["date_of_birth"], "expected a local midnight to keep its date")
+
+	_, err = hubSpot.MarshalProperties(struct {
+		Tags map[string]string `hubspot:"tags"`
+	}{})
+	assert.EqualError(t, err, "field Tags, err: unsupported property type map[string]string")
+}
+
+func TestUnmarshalProperties(t *testing.T) {
+	output := &hubSpot.ContactOutput{
+		Properties: map[string]string{
+			"firstname":                     "Peter",
+			"email":                         "pp@gmail.com",
+			"exos_perform_account_verified": "true",
+			"exos_sessions":                 "12.0",
+			"exos_score":                    "8.5",
+			"date_of_birth":                 "997401600000",
+			"exos_last_session":             "2021-01-12T15:47:54.554Z",
+			"lifecyclestage":                "customer",
+			"exos_sports":                   "football;tennis",
+		},
+	}
+
+	contact := testContact{Notes: "kept"}
+	err := output.Unmarshal(&contact)
+
+	assert.NoError(t, err)
+	lastSession := time.Date(2021, time.January, 12, 15, 47, 54, 554000000, time.UTC)
+	assert.Equal(t, testContact{
+		FirstName:      "Peter",
+		Email:          "pp@gmail.com",
+		Verified:       true,
+		Sessions:       12,
+		Score:          8.5,
+		Birthday:       time.Date(2001, time.August, 10, 0, 0, 0, 0, time.UTC),
+		LastSession:    &lastSession,
+		LifecycleStage: "customer",
+		Sports:         []string{"football", "tennis"},
+		Notes:          "kept",
+	}, contact)
+
+	output.Properties["exos_perform_account_verified"] = "maybe"
+	err = output.Unmarshal(&contact)
+	assert.Error(t, err, "expected invalid booleans to fail")
+
+	names, err := hubSpot.PropertyNames(contact)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"firstname", "email", "exos_perform_account_verified", "exos_sessions", "exos_score",
+		"date_of_birth", "exos_last_session", "lifecyclestage", "exos_sports",
+	}, names)
+}
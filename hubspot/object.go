@@ -0,0 +1,391 @@
+package hubspot
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CRM object types, custom objects are addressed by their object type ID (e.g. "2-1234567")
+const (
+	ObjectTypeContacts  = "contacts"
+	ObjectTypeProducts  = "products"
+	ObjectTypeLineItems = "line_items"
+	ObjectTypeQuotes    = "quotes"
+)
+
+// BatchLimit is the maximum number of inputs HubSpot accepts in a single batch request
+const BatchLimit = 100
+
+type (
+	// ObjectInput handles the body representation of any CRM object
+	ObjectInput struct {
+		Properties map[string]string `json:"properties"`
+	}
+
+	// ObjectOutput handles the representation of any CRM object
+	ObjectOutput struct {
+		ID         string            `json:"id"`
+		Properties map[string]string `json:"properties"`
+		CreatedAt  time.Time         `json:"createdAt"`
+		UpdatedAt  time.Time         `json:"updatedAt"`
+		Archived   bool              `json:"archived"`
+		ArchivedAt time.Time         `json:"archivedAt"`
+
+		PropertiesWithHistory map[string][]PropertyHistory  `json:"propertiesWithHistory,omitempty"`
+		Associations          map[string]ObjectAssociations `json:"associations,omitempty"`
+	}
+
+	// PropertyHistory handles a single past value of a property
+	PropertyHistory struct {
+		Value           string `json:"value"`
+		Timestamp       string `json:"timestamp"`
+		SourceType      string `json:"sourceType"`
+		SourceID        string `json:"sourceId"`
+		SourceLabel     string `json:"sourceLabel"`
+		UpdatedByUserID int    `json:"updatedByUserId"`
+	}
+
+	// ObjectAssociations handles the objects of one object type associated with a CRM object
+	ObjectAssociations struct {
+		Results []AssociatedObject `json:"results"`
+		Paging  *Paging            `json:"paging"`
+	}
+
+	// AssociatedObject handles a single object associated with a CRM object
+	AssociatedObject struct {
+		ID   string `json:"id"`
+		Type string `json:"type"`
+	}
+
+	// ReadOptions handles the optional query parameters when reading a CRM object
+	ReadOptions struct {
+		// Properties to be returned in the response, HubSpot's default set when empty
+		Properties []string
+		// PropertiesWithHistory to be returned in the response with their past values
+		PropertiesWithHistory []string
+		// Associations are the object types whose associated IDs are returned in the response
+		Associations []string
+		// IDProperty is a unique property identifying the object instead of its ID,
+		// contacts take it as an argument of ReadContactByIDProperty instead
+		IDProperty string
+		// Archived reads an archived object
+		Archived bool
+	}
+
+	// ListOptions handles the optional query parameters when listing CRM objects
+	ListOptions struct {
+		// Limit is the page size, HubSpot defaults to 10 and allows at most 100
+		Limit int
+		// After is the paging cursor returned with the previous page
+		After string
+		// Properties to be returned in the response, HubSpot's default set when empty
+		Properties []string
+		// Archived lists archived objects instead of active ones
+		Archived bool
+	}
+
+	// Paging handles the cursor to the next page of a HubSpot collection
+	Paging struct {
+		Next *PagingNext `json:"next"`
+	}
+
+	// PagingNext handles the position of the next page of a HubSpot collection
+	PagingNext struct {
+		After string `json:"after"`
+		Link  string `json:"link"`
+	}
+
+	// ObjectPage handles a page of CRM objects
+	ObjectPage struct {
+		Results []ObjectOutput `json:"results"`
+		Paging  *Paging        `json:"paging"`
+	}
+
+	// BatchReadOptions handles the optional parameters when reading CRM objects in a batch
+	BatchReadOptions struct {
+		// Properties to be returned in the response, HubSpot's default set when empty
+		Properties []string
+		// IDProperty is a unique property identifying the objects instead of their IDs
+		IDProperty string
+	}
+
+	// BatchUpdateInput handles a single object to update in a batch
+	BatchUpdateInput struct {
+		ID         string            `json:"id"`
+		Properties map[string]string `json:"properties"`
+	}
+
+	// BatchResults handles the results of a batch request, a multi-status response
+	// lists the inputs HubSpot could not process in Errors
+	BatchResults struct {
+		Status      string         `json:"status"`
+		Results     []ObjectOutput `json:"results"`
+		NumErrors   int            `json:"numErrors"`
+		Errors      []APIError     `json:"errors"`
+		StartedAt   string         `json:"startedAt"`
+		CompletedAt string         `json:"completedAt"`
+	}
+
+	// batchObjectID handles the ID of a single object in a batch
+	batchObjectID struct {
+		ID string `json:"id"`
+	}
+
+	// batchRequest handles the body of a batch request
+	batchRequest struct {
+		Properties []string    `json:"properties,omitempty"`
+		IDProperty string      `json:"idProperty,omitempty"`
+		Inputs     interface{} `json:"inputs"`
+	}
+)
+
+// ObjectsService handles the CRM objects API for a single object type
+type ObjectsService struct {
+	client     *Client
+	objectType string
+}
+
+// NewObjectInput creates a new CRM object Body representation
+func NewObjectInput(properties map[string]string) *ObjectInput {
+	return &ObjectInput{
+		Properties: properties,
+	}
+}
+
+// Objects returns the CRM objects API for an object type, e.g. ObjectTypeProducts
+// or the object type ID of a custom object
+func (c *Client) Objects(objectType string) *ObjectsService {
+	return &ObjectsService{
+		client:     c,
+		objectType: objectType,
+	}
+}
+
+// ObjectType returns the object type handled by the service
+func (s *ObjectsService) ObjectType() string {
+	return s.objectType
+}
+
+// Create creates a new object
+func (s *ObjectsService) Create(ctx context.Context, input *ObjectInput) (*ObjectOutput, error) {
+	apiURL := s.url("", nil)
+
+	var output ObjectOutput
+	if err := s.client.do(ctx, http.MethodPost, apiURL, input, http.StatusCreated, &output); err != nil {
+		return nil, err
+	}
+
+	return &output, nil
+}
+
+// Read gets an object by its ID, or by the unique property set in opts
+func (s *ObjectsService) Read(ctx context.Context, id string, opts *ReadOptions) (*ObjectOutput, error) {
+	if err := checkObjectID("object", id); err != nil {
+		return nil, err
+	}
+
+	apiURL := s.url(id, opts.query())
+
+	var output ObjectOutput
+	if err := s.client.do(ctx, http.MethodGet, apiURL, nil, http.StatusOK, &output); err != nil {
+		return nil, err
+	}
+
+	return &output, nil
+}
+
+// Update updates the properties of an object
+func (s *ObjectsService) Update(ctx context.Context, id string, input *ObjectInput) (*ObjectOutput, error) {
+	if err := checkObjectID("object", id); err != nil {
+		return nil, err
+	}
+
+	apiURL := s.url(id, nil)
+
+	var output ObjectOutput
+	if err := s.client.do(ctx, http.MethodPatch, apiURL, input, http.StatusOK, &output); err != nil {
+		return nil, err
+	}
+
+	return &output, nil
+}
+
+// Archive moves an object to the recycling bin
+func (s *ObjectsService) Archive(ctx context.Context, id string) error {
+	if err := checkObjectID("object", id); err != nil {
+		return err
+	}
+
+	return s.client.do(ctx, http.MethodDelete, s.url(id, nil), nil, http.StatusNoContent, nil)
+}
+
+// List gets a page of objects, pass Paging.Next.After of a page as opts.After for the next one
+func (s *ObjectsService) List(ctx context.Context, opts *ListOptions) (*ObjectPage, error) {
+	apiURL := s.url("", opts.query())
+
+	var page ObjectPage
+	if err := s.client.do(ctx, http.MethodGet, apiURL, nil, http.StatusOK, &page); err != nil {
+		return nil, err
+	}
+
+	return &page, nil
+}
+
+// Search gets a page of objects matching the search request, paging beyond
+// SearchResultLimit results, i.e. after plus limit exceeding it, returns ErrSearchResultLimit
+func (s *ObjectsService) Search(ctx context.Context, search *SearchRequest) (*SearchResults, error) {
+	if err := checkSearchLimit(search); err != nil {
+		return nil, err
+	}
+
+	apiURL := s.client.buildURL(s.client.objectPath(s.objectType, "")+"/search", nil)
+
+	var results SearchResults
+	if err := s.client.do(ctx, http.MethodPost, apiURL, search, http.StatusOK, &results); err != nil {
+		return nil, err
+	}
+
+	return &results, nil
+}
+
+// BatchCreate creates up to BatchLimit objects
+func (s *ObjectsService) BatchCreate(ctx context.Context, inputs []ObjectInput) (*BatchResults, error) {
+	if err := checkBatchSize(len(inputs)); err != nil {
+		return nil, err
+	}
+
+	var results BatchResults
+	if err := s.client.doBatch(ctx, s.batchURL("create"), batchRequest{Inputs: inputs}, http.StatusCreated, &results); err != nil {
+		return nil, err
+	}
+
+	return &results, nil
+}
+
+// BatchRead gets up to BatchLimit objects by their IDs, or by the unique property set in opts
+func (s *ObjectsService) BatchRead(ctx context.Context, ids []string, opts *BatchReadOptions) (*BatchResults, error) {
+	if err := checkBatchSize(len(ids)); err != nil {
+		return nil, err
+	}
+
+	body := batchRequest{Inputs: batchObjectIDs(ids)}
+	if opts != nil {
+		body.Properties = opts.Properties
+		body.IDProperty = opts.IDProperty
+	}
+
+	var results BatchResults
+	if err := s.client.doBatch(ctx, s.batchURL("read"), body, http.StatusOK, &results); err != nil {
+		return nil, err
+	}
+
+	return &results, nil
+}
+
+// BatchUpdate updates the properties of up to BatchLimit objects
+func (s *ObjectsService) BatchUpdate(ctx context.Context, inputs []BatchUpdateInput) (*BatchResults, error) {
+	if err := checkBatchSize(len(inputs)); err != nil {
+		return nil, err
+	}
+
+	var results BatchResults
+	if err := s.client.doBatch(ctx, s.batchURL("update"), batchRequest{Inputs: inputs}, http.StatusOK, &results); err != nil {
+		return nil, err
+	}
+
+	return &results, nil
+}
+
+// BatchArchive moves up to BatchLimit objects to the recycling bin
+func (s *ObjectsService) BatchArchive(ctx context.Context, ids []string) error {
+	if err := checkBatchSize(len(ids)); err != nil {
+		return err
+	}
+
+	return s.client.do(ctx, http.MethodPost, s.batchURL("archive"), batchRequest{Inputs: batchObjectIDs(ids)}, http.StatusNoContent, nil)
+}
+
+// url returns the URL of the object type, or of a single object when id is set
+func (s *ObjectsService) url(id string, query url.Values) string {
+	return s.client.buildURL(s.client.objectPath(s.objectType, id), query)
+}
+
+// batchURL returns the URL of a batch operation of the object type
+func (s *ObjectsService) batchURL(operation string) string {
+	return s.client.buildURL(s.client.objectPath(s.objectType, "")+"/batch/"+operation, nil)
+}
+
+// NextAfter returns the cursor of the next page, or an empty string on the last page
+func (p *Paging) NextAfter() string {
+	if p == nil || p.Next == nil {
+		return ""
+	}
+	return p.Next.After
+}
+
+// query returns the query parameters of the read options
+func (o *ReadOptions) query() url.Values {
+	query := url.Values{}
+	if o == nil {
+		return query
+	}
+	if len(o.Properties) > 0 {
+		query.Set("properties", strings.Join(o.Properties, ","))
+	}
+	if len(o.PropertiesWithHistory) > 0 {
+		query.Set("propertiesWithHistory", strings.Join(o.PropertiesWithHistory, ","))
+	}
+	if len(o.Associations) > 0 {
+		query.Set("associations", strings.Join(o.Associations, ","))
+	}
+	if o.IDProperty != "" {
+		query.Set("idProperty", o.IDProperty)
+	}
+	if o.Archived {
+		query.Set("archived", "true")
+	}
+	return query
+}
+
+// query returns the query parameters of the list options
+func (o *ListOptions) query() url.Values {
+	query := url.Values{}
+	if o == nil {
+		return query
+	}
+	if o.Limit > 0 {
+		query.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.After != "" {
+		query.Set("after", o.After)
+	}
+	if len(o.Properties) > 0 {
+		query.Set("properties", strings.Join(o.Properties, ","))
+	}
+	if o.Archived {
+		query.Set("archived", "true")
+	}
+	return query
+}
+
+// checkBatchSize makes sure a batch holds between one and BatchLimit inputs
+func checkBatchSize(size int) error {
+	if size == 0 || size > BatchLimit {
+		return fmt.Errorf("batch requires between 1 and %d inputs, got %d", BatchLimit, size)
+	}
+	return nil
+}
+
+// batchObjectIDs converts object IDs to batch inputs
+func batchObjectIDs(ids []string) []batchObjectID {
+	inputs := make([]batchObjectID, len(ids))
+	for i, id := range ids {
+		inputs[i] = batchObjectID{ID: id}
+	}
+	return inputs
+}
@@ -0,0 +1,142 @@
+package hubspot_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	hubSpot "github.com/teamexos/hubspot-api-go/hubspot"
+)
+
+func TestObjectsRead(t *testing.T) {
+	c := hubSpot.NewClient("fake-api-key")
+
+	var gotRequest *http.Request
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			gotRequest = req
+			return newMockResponse(http.StatusOK, `{
+				"id": "901",
+				"properties": {"coach_name": "Sam"},
+				"createdAt": "2021-01-12T15:47:54.554Z",
+				"updatedAt": "2021-01-12T15:47:54.554Z",
+				"archived": false
+			}`), nil
+		},
+	}
+
+	object, err := c.Objects("2-1234567").Read(context.Background(), "901", &hubSpot.ReadOptions{
+		Properties: []string{"coach_name", "coach_level"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "/crm/v3/objects/2-1234567/901", gotRequest.URL.Path)
+	assert.Equal(t, "coach_name,coach_level", gotRequest.URL.Query().Get("properties"))
+	assert.Equal(t, "Sam", object.Properties["coach_name"])
+	assert.Equal(t, time.Date(2021, time.January, 12, 15, 47, 54, 554000000, time.UTC), object.CreatedAt.UTC())
+}
+
+func TestObjectsRequireID(t *testing.T) {
+	c := hubSpot.NewClient("fake-api-key")
+
+	calls := 0
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			return newMockResponse(http.StatusOK, `{"results": []}`), nil
+		},
+	}
+	objects := c.Objects("2-1234567")
+
+	_, err := objects.Read(context.Background(), "", nil)
+	assert.EqualError(t, err, "object identifier requires a value")
+
+	_, err = objects.Update(context.Background(), " ", &hubSpot.ObjectInput{})
+	assert.EqualError(t, err, "object identifier requires a value")
+
+	err = objects.Archive(context.Background(), "")
+	assert.EqualError(t, err, "object identifier requires a value")
+
+	assert.Equal(t, 0, calls, "expected no request to be sent")
+}
+
+func TestObjectsList(t *testing.T) {
+	c := hubSpot.NewClient("fake-api-key")
+
+	var gotRequest *http.Request
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			gotRequest = req
+			return newMockResponse(http.StatusOK, `{
+				"results": [
+					{"id": "1", "properties": {"name": "Membership"}},
+					{"id": "2", "properties": {"name": "Coaching"}}
+				],
+				"paging": {"next": {"after": "2", "link": "https://api.hubapi.com/crm/v3/objects/products?after=2"}}
+			}`), nil
+		},
+	}
+
+	page, err := c.Objects(hubSpot.ObjectTypeProducts).List(context.Background(), &hubSpot.ListOptions{Limit: 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "/crm/v3/objects/products", gotRequest.URL.Path)
+	assert.Equal(t, "2", gotRequest.URL.Query().Get("limit"))
+	assert.Len(t, page.Results, 2)
+	assert.Equal(t, "2", page.Paging.Next.After)
+}
+
+func TestObjectsBatchCreate(t *testing.T) {
+	c := hubSpot.NewClient("fake-api-key")
+
+	var gotBody map[string][]hubSpot.ObjectInput
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "/crm/v3/objects/line_items/batch/create", req.URL.Path)
+			body, _ := ioutil.ReadAll(req.Body)
+			assert.NoError(t, json.Unmarshal(body, &gotBody))
+			return newMockResponse(http.StatusMultiStatus, `{
+				"status": "COMPLETE",
+				"results": [{"id": "11", "properties": {"name": "Membership"}}],
+				"numErrors": 1,
+				"errors": [
+					{
+						"status": "error",
+						"category": "VALIDATION_ERROR",
+						"message": "Property values were not valid"
+					}
+				]
+			}`), nil
+		},
+	}
+
+	results, err := c.Objects(hubSpot.ObjectTypeLineItems).BatchCreate(context.Background(), []hubSpot.ObjectInput{
+		{Properties: map[string]string{"name": "Membership"}},
+		{Properties: map[string]string{"name": "Coaching", "price": "free"}},
+	})
+
+	assert.NoError(t, err, "expected a multi-status response to return the results")
+	assert.Len(t, gotBody["inputs"], 2)
+	assert.Len(t, results.Results, 1)
+	assert.Equal(t, 1, results.NumErrors)
+	assert.Equal(t, hubSpot.CategoryValidationError, results.Errors[0].Category)
+}
+
+func TestObjectsBatchLimit(t *testing.T) {
+	c := hubSpot.NewClient("fake-api-key")
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return nil, errors.New("expected no request to be sent")
+		},
+	}
+
+	ids := make([]string, hubSpot.BatchLimit+1)
+	err := c.Objects(hubSpot.ObjectTypeDeals).BatchArchive(context.Background(), ids)
+
+	assert.EqualError(t, err, "batch requires between 1 and 100 inputs, got 101")
+}
@@ -0,0 +1,330 @@
+package hubspot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Property types, the data type of a property value
+const (
+	PropertyTypeBool        = "bool"
+	PropertyTypeEnumeration = "enumeration"
+	PropertyTypeDate        = "date"
+	PropertyTypeDateTime    = "datetime"
+	PropertyTypeString      = "string"
+	PropertyTypeNumber      = "number"
+	PropertyTypePhoneNumber = "phone_number"
+)
+
+// Property field types, how a property is displayed in HubSpot and on forms
+const (
+	PropertyFieldTypeBooleanCheckbox     = "booleancheckbox"
+	PropertyFieldTypeCheckbox            = "checkbox"
+	PropertyFieldTypeDate                = "date"
+	PropertyFieldTypeFile                = "file"
+	PropertyFieldTypeNumber              = "number"
+	PropertyFieldTypeRadio               = "radio"
+	PropertyFieldTypeSelect              = "select"
+	PropertyFieldTypeText                = "text"
+	PropertyFieldTypeTextarea            = "textarea"
+	PropertyFieldTypePhoneNumber         = "phonenumber"
+	PropertyFieldTypeCalculationEquation = "calculation_equation"
+)
+
+type (
+	// Property handles a property definition representation from HubSpot
+	Property struct {
+		Name                 string                        `json:"name"`
+		Label                string                        `json:"label"`
+		Type                 string                        `json:"type"`
+		FieldType            string                        `json:"fieldType"`
+		Description          string                        `json:"description"`
+		GroupName            string                        `json:"groupName"`
+		Options              []PropertyOption              `json:"options"`
+		DisplayOrder         int                           `json:"displayOrder"`
+		Calculated           bool                          `json:"calculated"`
+		CalculationFormula   string                        `json:"calculationFormula,omitempty"`
+		ExternalOptions      bool                          `json:"externalOptions"`
+		HasUniqueValue       bool                          `json:"hasUniqueValue"`
+		Hidden               bool                          `json:"hidden"`
+		FormField            bool                          `json:"formField"`
+		HubSpotDefined       bool                          `json:"hubspotDefined"`
+		ReferencedObjectType string                        `json:"referencedObjectType,omitempty"`
+		ModificationMetadata *PropertyModificationMetadata `json:"modificationMetadata,omitempty"`
+		CreatedAt            time.Time                     `json:"createdAt"`
+		UpdatedAt            time.Time                     `json:"updatedAt"`
+		CreatedUserID        string                        `json:"createdUserId,omitempty"`
+		UpdatedUserID        string                        `json:"updatedUserId,omitempty"`
+		Archived             bool                          `json:"archived"`
+		ArchivedAt           time.Time                     `json:"archivedAt"`
+	}
+
+	// PropertyOption handles a single option of an enumeration property
+	PropertyOption struct {
+		Label        string `json:"label"`
+		Value        string `json:"value"`
+		Description  string `json:"description,omitempty"`
+		DisplayOrder int    `json:"displayOrder"`
+		Hidden       bool   `json:"hidden"`
+	}
+
+	// PropertyModificationMetadata handles which parts of a property can be changed
+	PropertyModificationMetadata struct {
+		Archivable         bool `json:"archivable"`
+		ReadOnlyDefinition bool `json:"readOnlyDefinition"`
+		ReadOnlyValue      bool `json:"readOnlyValue"`
+		ReadOnlyOptions    bool `json:"readOnlyOptions,omitempty"`
+	}
+
+	// PropertyInput handles the body representation of a new property
+	PropertyInput struct {
+		Name               string           `json:"name"`
+		Label              string           `json:"label"`
+		Type               string           `json:"type"`
+		FieldType          string           `json:"fieldType"`
+		GroupName          string           `json:"groupName"`
+		Description        string           `json:"description,omitempty"`
+		Options            []PropertyOption `json:"options,omitempty"`
+		DisplayOrder       int              `json:"displayOrder,omitempty"`
+		CalculationFormula string           `json:"calculationFormula,omitempty"`
+		HasUniqueValue     bool             `json:"hasUniqueValue,omitempty"`
+		Hidden             bool             `json:"hidden,omitempty"`
+		FormField          bool             `json:"formField,omitempty"`
+		ExternalOptions    bool             `json:"externalOptions,omitempty"`
+	}
+
+	// PropertyUpdateInput handles the body representation of a property update,
+	// only the fields that are set are changed
+	PropertyUpdateInput struct {
+		Label              string `json:"label,omitempty"`
+		Type               string `json:"type,omitempty"`
+		FieldType          string `json:"fieldType,omitempty"`
+		GroupName          string `json:"groupName,omitempty"`
+		Description        string `json:"description,omitempty"`
+		CalculationFormula string `json:"calculationFormula,omitempty"`
+		// Options replace every option of an enumeration property
+		Options      []PropertyOption `json:"options,omitempty"`
+		DisplayOrder *int             `json:"displayOrder,omitempty"`
+		Hidden       *bool            `json:"hidden,omitempty"`
+		FormField    *bool            `json:"formField,omitempty"`
+	}
+
+	// PropertyGroup handles a property group representation from HubSpot
+	PropertyGroup struct {
+		Name         string `json:"name"`
+		Label        string `json:"label"`
+		DisplayOrder int    `json:"displayOrder"`
+		Archived     bool   `json:"archived"`
+	}
+
+	// PropertyGroupInput handles the body representation of a new property group
+	PropertyGroupInput struct {
+		Name         string `json:"name"`
+		Label        string `json:"label"`
+		DisplayOrder int    `json:"displayOrder,omitempty"`
+	}
+
+	// PropertyGroupUpdateInput handles the body representation of a property group update,
+	// only the fields that are set are changed
+	PropertyGroupUpdateInput struct {
+		Label        string `json:"label,omitempty"`
+		DisplayOrder *int   `json:"displayOrder,omitempty"`
+	}
+
+	// PropertyListOptions handles the optional query parameters when listing properties
+	PropertyListOptions struct {
+		// Archived lists archived properties instead of active ones
+		Archived bool
+	}
+
+	// propertyResults handles the properties of an object type
+	propertyResults struct {
+		Results []Property `json:"results"`
+	}
+
+	// propertyGroupResults handles the property groups of an object type
+	propertyGroupResults struct {
+		Results []PropertyGroup `json:"results"`
+	}
+)
+
+// PropertiesService handles the CRM properties API for a single object type
+type PropertiesService struct {
+	client     *Client
+	objectType string
+}
+
+// Properties returns the CRM properties API for an object type, e.g. ObjectTypeContacts
+// or the object type ID of a custom object
+func (c *Client) Properties(objectType string) *PropertiesService {
+	return &PropertiesService{
+		client:     c,
+		objectType: objectType,
+	}
+}
+
+// ObjectType returns the object type handled by the service
+func (s *PropertiesService) ObjectType() string {
+	return s.objectType
+}
+
+// List gets every property of the object type
+func (s *PropertiesService) List(ctx context.Context, opts *PropertyListOptions) ([]Property, error) {
+	query := url.Values{}
+	if opts != nil && opts.Archived {
+		query.Set("archived", "true")
+	}
+
+	var results propertyResults
+	if err := s.client.do(ctx, http.MethodGet, s.url("", query), nil, http.StatusOK, &results); err != nil {
+		return nil, err
+	}
+
+	return results.Results, nil
+}
+
+// Read gets a property by its name
+func (s *PropertiesService) Read(ctx context.Context, name string) (*Property, error) {
+	if name == "" {
+		return nil, errors.New("property name requires a value")
+	}
+
+	var property Property
+	if err := s.client.do(ctx, http.MethodGet, s.url(name, nil), nil, http.StatusOK, &property); err != nil {
+		return nil, err
+	}
+
+	return &property, nil
+}
+
+// Create creates a new property
+func (s *PropertiesService) Create(ctx context.Context, input *PropertyInput) (*Property, error) {
+	var property Property
+	if err := s.client.do(ctx, http.MethodPost, s.url("", nil), input, http.StatusCreated, &property); err != nil {
+		return nil, err
+	}
+
+	return &property, nil
+}
+
+// Update updates a property by its name
+func (s *PropertiesService) Update(ctx context.Context, name string, input *PropertyUpdateInput) (*Property, error) {
+	if name == "" {
+		return nil, errors.New("property name requires a value")
+	}
+
+	var property Property
+	if err := s.client.do(ctx, http.MethodPatch, s.url(name, nil), input, http.StatusOK, &property); err != nil {
+		return nil, err
+	}
+
+	return &property, nil
+}
+
+// UpdateOptions replaces every option of an enumeration property
+func (s *PropertiesService) UpdateOptions(ctx context.Context, name string, options []PropertyOption) (*Property, error) {
+	if len(options) == 0 {
+		return nil, fmt.Errorf("enumeration property %s requires at least one option", name)
+	}
+	return s.Update(ctx, name, &PropertyUpdateInput{Options: options})
+}
+
+// Archive moves a property to the recycling bin
+func (s *PropertiesService) Archive(ctx context.Context, name string) error {
+	if name == "" {
+		return errors.New("property name requires a value")
+	}
+	return s.client.do(ctx, http.MethodDelete, s.url(name, nil), nil, http.StatusNoContent, nil)
+}
+
+// ListGroups gets every property group of the object type
+func (s *PropertiesService) ListGroups(ctx context.Context) ([]PropertyGroup, error) {
+	var results propertyGroupResults
+	if err := s.client.do(ctx, http.MethodGet, s.groupURL(""), nil, http.StatusOK, &results); err != nil {
+		return nil, err
+	}
+
+	return results.Results, nil
+}
+
+// ReadGroup gets a property group by its name
+func (s *PropertiesService) ReadGroup(ctx context.Context, name string) (*PropertyGroup, error) {
+	if name == "" {
+		return nil, errors.New("property group name requires a value")
+	}
+
+	var group PropertyGroup
+	if err := s.client.do(ctx, http.MethodGet, s.groupURL(name), nil, http.StatusOK, &group); err != nil {
+		return nil, err
+	}
+
+	return &group, nil
+}
+
+// CreateGroup creates a new property group
+func (s *PropertiesService) CreateGroup(ctx context.Context, input *PropertyGroupInput) (*PropertyGroup, error) {
+	var group PropertyGroup
+	if err := s.client.do(ctx, http.MethodPost, s.groupURL(""), input, http.StatusCreated, &group); err != nil {
+		return nil, err
+	}
+
+	return &group, nil
+}
+
+// UpdateGroup updates a property group by its name
+func (s *PropertiesService) UpdateGroup(
+	ctx context.Context,
+	name string,
+	input *PropertyGroupUpdateInput) (*PropertyGroup, error) {
+
+	if name == "" {
+		return nil, errors.New("property group name requires a value")
+	}
+
+	var group PropertyGroup
+	if err := s.client.do(ctx, http.MethodPatch, s.groupURL(name), input, http.StatusOK, &group); err != nil {
+		return nil, err
+	}
+
+	return &group, nil
+}
+
+// ArchiveGroup archives a property group, its properties must be moved to another group first
+func (s *PropertiesService) ArchiveGroup(ctx context.Context, name string) error {
+	if name == "" {
+		return errors.New("property group name requires a value")
+	}
+	return s.client.do(ctx, http.MethodDelete, s.groupURL(name), nil, http.StatusNoContent, nil)
+}
+
+// url returns the URL of the properties of the object type, or of a single property when name is set
+func (s *PropertiesService) url(name string, query url.Values) string {
+	path := fmt.Sprintf("/crm/%s/properties/%s", s.client.APIVersion, s.objectType)
+	if name != "" {
+		path += "/" + url.PathEscape(name)
+	}
+	return s.client.buildURL(path, query)
+}
+
+// groupURL returns the URL of the property groups of the object type, or of a single group when name is set
+func (s *PropertiesService) groupURL(name string) string {
+	path := fmt.Sprintf("/crm/%s/properties/%s/groups", s.client.APIVersion, s.objectType)
+	if name != "" {
+		path += "/" + url.PathEscape(name)
+	}
+	return s.client.buildURL(path, nil)
+}
+
+// IsEnumeration reports whether the property holds one or more of its options
+func (p *Property) IsEnumeration() bool {
+	return p.Type == PropertyTypeEnumeration
+}
+
+// IsReadOnly reports whether HubSpot rejects values written to the property
+func (p *Property) IsReadOnly() bool {
+	return p.Calculated || (p.ModificationMetadata != nil && p.ModificationMetadata.ReadOnlyValue)
+}
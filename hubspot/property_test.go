@@ -0,0 +1,160 @@
+package hubspot_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	hubSpot "github.com/teamexos/hubspot-api-go/hubspot"
+)
+
+const verifiedPropertyJSON = `{
+	"name": "exos_perform_account_verified",
+	"label": "EXOS Perform account verified",
+	"type": "enumeration",
+	"fieldType": "booleancheckbox",
+	"description": "",
+	"groupName": "exos",
+	"options": [
+		{"label": "Yes", "value": "true", "displayOrder": 0, "hidden": false},
+		{"label": "No", "value": "false", "displayOrder": 1, "hidden": false}
+	],
+	"displayOrder": -1,
+	"calculated": false,
+	"externalOptions": false,
+	"hasUniqueValue": false,
+	"hidden": false,
+	"formField": true,
+	"modificationMetadata": {"archivable": true, "readOnlyDefinition": false, "readOnlyValue": false},
+	"createdAt": "2020-10-14T18:01:05.763Z",
+	"updatedAt": "2020-10-14T18:01:05.763Z",
+	"archived": false
+}`
+
+func TestPropertiesList(t *testing.T) {
+	c := hubSpot.NewClient("fake-api-key")
+
+	var gotRequest *http.Request
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			gotRequest = req
+			return newMockResponse(http.StatusOK, `{"results": [`+verifiedPropertyJSON+`]}`), nil
+		},
+	}
+
+	properties, err := c.Properties(hubSpot.ObjectTypeContacts).List(context.Background(), &hubSpot.PropertyListOptions{
+		Archived: true,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "/crm/v3/properties/contacts", gotRequest.URL.Path)
+	assert.Equal(t, "true", gotRequest.URL.Query().Get("archived"))
+	assert.Len(t, properties, 1)
+	assert.Equal(t, "exos_perform_account_verified", properties[0].Name)
+	assert.True(t, properties[0].IsEnumeration())
+	assert.False(t, properties[0].IsReadOnly())
+	assert.Equal(t, "false", properties[0].Options[1].Value)
+}
+
+func TestPropertiesCreateAndUpdate(t *testing.T) {
+	c := hubSpot.NewClient("fake-api-key")
+
+	var gotRequest *http.Request
+	var gotBody map[string]interface{}
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			gotRequest = req
+			body, _ := ioutil.ReadAll(req.Body)
+			gotBody = nil
+			assert.NoError(t, json.Unmarshal(body, &gotBody))
+			if req.Method == http.MethodPost {
+				return newMockResponse(http.StatusCreated, verifiedPropertyJSON), nil
+			}
+			return newMockResponse(http.StatusOK, verifiedPropertyJSON), nil
+		},
+	}
+	properties := c.Properties(hubSpot.ObjectTypeContacts)
+
+	property, err := properties.Create(context.Background(), &hubSpot.PropertyInput{
+		Name:      "exos_perform_account_verified",
+		Label:     "EXOS Perform account verified",
+		Type:      hubSpot.PropertyTypeEnumeration,
+		FieldType: hubSpot.PropertyFieldTypeBooleanCheckbox,
+		GroupName: "exos",
+		Options: []hubSpot.PropertyOption{
+			{Label: "Yes", Value: "true"},
+			{Label: "No", Value: "false", DisplayOrder: 1},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPost, gotRequest.Method)
+	assert.Equal(t, "/crm/v3/properties/contacts", gotRequest.URL.Path)
+	assert.Equal(t, "exos_perform_account_verified", gotBody["name"])
+	assert.Len(t, gotBody["options"], 2)
+	assert.Equal(t, "exos", property.GroupName)
+
+	hidden := false
+	_, err = properties.Update(context.Background(), "exos_perform_account_verified", &hubSpot.PropertyUpdateInput{
+		Label:  "Perform account verified",
+		Hidden: &hidden,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPatch, gotRequest.Method)
+	assert.Equal(t, "/crm/v3/properties/contacts/exos_perform_account_verified", gotRequest.URL.Path)
+	assert.Equal(t, map[string]interface{}{"label": "Perform account verified", "hidden": false}, gotBody,
+		"expected only the set fields to be sent")
+
+	_, err = properties.UpdateOptions(context.Background(), "exos_perform_account_verified", nil)
+	assert.Error(t, err, "expected an enumeration to require options")
+}
+
+func TestPropertyGroups(t *testing.T) {
+	c := hubSpot.NewClient("fake-api-key")
+
+	var gotRequests []string
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			gotRequests = append(gotRequests, req.Method+" "+req.URL.Path)
+			switch req.Method {
+			case http.MethodGet:
+				return newMockResponse(http.StatusOK, `{"results": [
+					{"name": "contactinformation", "label": "Contact information", "displayOrder": -1, "archived": false},
+					{"name": "exos", "label": "EXOS", "displayOrder": 5, "archived": false}
+				]}`), nil
+			case http.MethodPost:
+				return newMockResponse(http.StatusCreated, `{"name": "exos_coaching", "label": "EXOS coaching", "displayOrder": 6}`), nil
+			default:
+				return newMockResponse(http.StatusNotFound, `{"status": "error", "category": "OBJECT_NOT_FOUND"}`), nil
+			}
+		},
+	}
+	properties := c.Properties(hubSpot.ObjectTypeContacts)
+
+	groups, err := properties.ListGroups(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, groups, 2)
+	assert.Equal(t, "exos", groups[1].Name)
+
+	group, err := properties.CreateGroup(context.Background(), &hubSpot.PropertyGroupInput{
+		Name:         "exos_coaching",
+		Label:        "EXOS coaching",
+		DisplayOrder: 6,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "exos_coaching", group.Name)
+
+	err = properties.ArchiveGroup(context.Background(), "exos_legacy")
+	assert.True(t, errors.Is(err, hubSpot.ErrNotFound), "expected a not found error")
+
+	assert.Equal(t, []string{
+		"GET /crm/v3/properties/contacts/groups",
+		"POST /crm/v3/properties/contacts/groups",
+		"DELETE /crm/v3/properties/contacts/groups/exos_legacy",
+	}, gotRequests)
+}
@@ -0,0 +1,161 @@
+package hubspot
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// HubSpot rate limit response headers
+const (
+	HeaderRateLimitMax                  = "X-HubSpot-RateLimit-Max"
+	HeaderRateLimitRemaining            = "X-HubSpot-RateLimit-Remaining"
+	HeaderRateLimitIntervalMilliseconds = "X-HubSpot-RateLimit-Interval-Milliseconds"
+	HeaderRateLimitDaily                = "X-HubSpot-RateLimit-Daily"
+	HeaderRateLimitDailyRemaining       = "X-HubSpot-RateLimit-Daily-Remaining"
+)
+
+// RateLimitQuota handles the rate limit state last reported by HubSpot
+type RateLimitQuota struct {
+	// Max is the number of requests allowed per Interval
+	Max int
+	// Remaining is the number of requests left in the current interval
+	Remaining int
+	// Interval is the length of the rolling rate limit window, usually 10 seconds
+	Interval time.Duration
+	// ResetAt is the estimated end of the current interval
+	ResetAt time.Time
+	// Daily is the number of requests allowed per day
+	Daily int
+	// DailyRemaining is the number of requests left today, as last reported by HubSpot.
+	// The daily quota resets at midnight in the account's time zone and is not paced,
+	// HubSpot rejects requests beyond it with ErrRateLimited.
+	DailyRemaining int
+	// UpdatedAt is the time the quota was last reported by HubSpot
+	UpdatedAt time.Time
+}
+
+// RateLimiter paces requests to stay within the quota HubSpot reports in its
+// X-HubSpot-RateLimit-* response headers. A single RateLimiter is safe to share
+// between goroutines, and between clients authenticating against the same account.
+type RateLimiter struct {
+	mu    sync.Mutex
+	quota RateLimitQuota
+	now   func() time.Time
+}
+
+// NewRateLimiter creates a new RateLimiter, it does not delay requests until
+// HubSpot reports a quota
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{
+		now: time.Now,
+	}
+}
+
+// Quota returns the current rate limit state
+func (l *RateLimiter) Quota() RateLimitQuota {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill(l.now())
+	return l.quota
+}
+
+// wait blocks until the quota allows another request, or ctx is done
+func (l *RateLimiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	for {
+		l.mu.Lock()
+		now := l.now()
+		l.refill(now)
+
+		if l.quota.Max <= 0 || l.quota.Remaining > 0 {
+			if l.quota.Max > 0 {
+				l.quota.Remaining--
+			}
+			l.mu.Unlock()
+			return nil
+		}
+
+		delay := l.quota.ResetAt.Sub(now)
+		l.mu.Unlock()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// observe updates the quota from the headers of a HubSpot response
+func (l *RateLimiter) observe(statusCode int, header http.Header) {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.refill(now)
+
+	if max, ok := headerInt(header, HeaderRateLimitMax); ok {
+		l.quota.Max = max
+		l.quota.UpdatedAt = now
+	}
+	if interval, ok := headerInt(header, HeaderRateLimitIntervalMilliseconds); ok {
+		l.quota.Interval = time.Duration(interval) * time.Millisecond
+	}
+	if l.quota.Max > 0 && l.quota.ResetAt.IsZero() {
+		l.quota.Remaining = l.quota.Max
+		l.quota.ResetAt = now.Add(l.quota.Interval)
+	}
+	// responses of concurrent requests arrive out of order, so the lowest
+	// remaining count seen in the current interval wins
+	if remaining, ok := headerInt(header, HeaderRateLimitRemaining); ok && remaining < l.quota.Remaining {
+		l.quota.Remaining = remaining
+	}
+
+	if daily, ok := headerInt(header, HeaderRateLimitDaily); ok {
+		l.quota.Daily = daily
+		l.quota.UpdatedAt = now
+	}
+	// the daily quota resets at an unknown time, so the reported count always replaces it
+	if dailyRemaining, ok := headerInt(header, HeaderRateLimitDailyRemaining); ok {
+		l.quota.DailyRemaining = dailyRemaining
+	}
+
+	// HubSpot rejected the request, hold back everyone until the interval resets
+	if statusCode == http.StatusTooManyRequests && l.quota.Max > 0 {
+		l.quota.Remaining = 0
+	}
+}
+
+// refill resets the interval quota once its window has passed, the caller must hold l.mu
+func (l *RateLimiter) refill(now time.Time) {
+	if l.quota.Max > 0 && !now.Before(l.quota.ResetAt) {
+		l.quota.Remaining = l.quota.Max
+		l.quota.ResetAt = now.Add(l.quota.Interval)
+	}
+}
+
+// headerInt reads a header holding a non-negative integer
+func headerInt(header http.Header, key string) (int, bool) {
+	value := header.Get(key)
+	if value == "" {
+		return 0, false
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	return n, true
+}
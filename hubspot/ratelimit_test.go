@@ -0,0 +1,90 @@
+package hubspot_test
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	hubSpot "github.com/teamexos/hubspot-api-go/hubspot"
+)
+
+func newRateLimitedResponse(max int, remaining int, interval time.Duration) *http.Response {
+	r := newMockResponse(http.StatusOK, `{"id": "3100", "properties": {"email": "pp@gmail.com"}}`)
+	r.Header.Set(hubSpot.HeaderRateLimitMax, strconv.Itoa(max))
+	r.Header.Set(hubSpot.HeaderRateLimitRemaining, strconv.Itoa(remaining))
+	r.Header.Set(hubSpot.HeaderRateLimitIntervalMilliseconds, strconv.Itoa(int(interval/time.Millisecond)))
+	r.Header.Set(hubSpot.HeaderRateLimitDaily, "250000")
+	r.Header.Set(hubSpot.HeaderRateLimitDailyRemaining, "249000")
+	return r
+}
+
+func TestRateLimiterQuota(t *testing.T) {
+	c := hubSpot.NewClient("fake-api-key")
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return newRateLimitedResponse(100, 42, 10*time.Second), nil
+		},
+	}
+
+	_, err := c.ReadContact("pp@gmail.com", "email")
+	assert.NoError(t, err)
+
+	quota := c.RateLimiter.Quota()
+	assert.Equal(t, 100, quota.Max)
+	assert.Equal(t, 42, quota.Remaining)
+	assert.Equal(t, 10*time.Second, quota.Interval)
+	assert.Equal(t, 250000, quota.Daily)
+	assert.Equal(t, 249000, quota.DailyRemaining)
+}
+
+func TestRateLimiterPacing(t *testing.T) {
+	interval := 100 * time.Millisecond
+
+	c := hubSpot.NewClient("fake-api-key")
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return newRateLimitedResponse(2, 0, interval), nil
+		},
+	}
+
+	// the first response reports an exhausted quota, so the next request waits
+	// for the interval to reset
+	_, err := c.ReadContact("pp@gmail.com", "email")
+	assert.NoError(t, err)
+
+	start := time.Now()
+	_, err = c.ReadContact("pp@gmail.com", "email")
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(interval/2), "expected the request to wait for the quota")
+}
+
+func TestRateLimiterDailyQuota(t *testing.T) {
+	dailyRemaining := 0
+	calls := 0
+
+	c := hubSpot.NewClient("fake-api-key")
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			r := newRateLimitedResponse(100, 99, 10*time.Second)
+			r.Header.Set(hubSpot.HeaderRateLimitDailyRemaining, strconv.Itoa(dailyRemaining))
+			return r, nil
+		},
+	}
+
+	_, err := c.ReadContact("pp@gmail.com", "email")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, c.RateLimiter.Quota().DailyRemaining)
+
+	// the daily quota was reset by HubSpot in the account's time zone
+	dailyRemaining = 100
+	for i := 0; i < 3; i++ {
+		_, err = c.ReadContact("pp@gmail.com", "email")
+		assert.NoError(t, err, "expected the request not to be refused locally")
+	}
+
+	assert.Equal(t, 4, calls)
+	assert.Equal(t, 100, c.RateLimiter.Quota().DailyRemaining, "expected the reported count to replace the estimate")
+}
@@ -0,0 +1,155 @@
+package hubspot
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Retry defaults
+const (
+	DefaultMaxRetries     = 3
+	DefaultRetryBaseDelay = 500 * time.Millisecond
+	DefaultRetryMaxDelay  = 30 * time.Second
+)
+
+// RetryPolicy configures how the client retries failed requests. Rate limited (429)
+// responses are retried for every method, because HubSpot rejects them before
+// processing. Server errors and connection failures are only retried for idempotent
+// methods unless RetryNonIdempotent is set.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt
+	MaxRetries int
+	// BaseDelay is the backoff before the first retry, doubled for every following retry
+	BaseDelay time.Duration
+	// MaxDelay caps the exponential backoff, a Retry-After header may exceed it
+	MaxDelay time.Duration
+	// RetryNonIdempotent allows POST and PATCH requests to be replayed after server
+	// errors and connection failures
+	RetryNonIdempotent bool
+	// RetryableStatusCodes are the response status codes worth another attempt
+	RetryableStatusCodes []int
+}
+
+// DefaultRetryPolicy returns a RetryPolicy retrying 429, 502, 503 and 504 responses
+// and connection errors with jittered exponential backoff
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxRetries: DefaultMaxRetries,
+		BaseDelay:  DefaultRetryBaseDelay,
+		MaxDelay:   DefaultRetryMaxDelay,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// backoff reports whether a failed attempt should be retried and how long to wait first
+func (p *RetryPolicy) backoff(method string, attempt int, response *Response, err error) (time.Duration, bool) {
+	if p == nil || attempt >= p.MaxRetries {
+		return 0, false
+	}
+
+	if response != nil && response.StatusCode != 0 {
+		if !p.retryableStatus(response.StatusCode) {
+			return 0, false
+		}
+		if response.StatusCode != http.StatusTooManyRequests && !p.replayable(method) {
+			return 0, false
+		}
+		if retryAfter, ok := parseRetryAfter(response.Header, time.Now()); ok {
+			return retryAfter, true
+		}
+		return p.delay(attempt), true
+	}
+
+	var tErr *transportError
+	if !errors.As(err, &tErr) || !p.replayable(method) {
+		return 0, false
+	}
+
+	// a cancelled or expired context will fail every following attempt too
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return 0, false
+	}
+
+	return p.delay(attempt), true
+}
+
+// retryableStatus reports whether statusCode is one of the retryable status codes
+func (p *RetryPolicy) retryableStatus(statusCode int) bool {
+	for _, code := range p.RetryableStatusCodes {
+		if code == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// replayable reports whether a request with the given method may be sent again
+func (p *RetryPolicy) replayable(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return p.RetryNonIdempotent
+}
+
+// delay returns a random duration between zero and the capped exponential backoff
+func (p *RetryPolicy) delay(attempt int) time.Duration {
+	backoff := p.BaseDelay
+	for i := 0; i < attempt && (p.MaxDelay <= 0 || backoff < p.MaxDelay); i++ {
+		backoff *= 2
+	}
+	if p.MaxDelay > 0 && backoff > p.MaxDelay {
+		backoff = p.MaxDelay
+	}
+	if backoff <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(backoff) + 1))
+}
+
+// parseRetryAfter reads a Retry-After header given either in seconds or as an HTTP date
+func parseRetryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := date.Sub(now); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
+
+// sleepContext waits for the given duration or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,96 @@
+package hubspot_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	hubSpot "github.com/teamexos/hubspot-api-go/hubspot"
+)
+
+func newRetryTestClient(responses []*http.Response, calls *int) *hubSpot.Client {
+	c := hubSpot.NewClient("fake-api-key")
+	c.RetryPolicy = hubSpot.DefaultRetryPolicy()
+	c.RetryPolicy.BaseDelay = time.Millisecond
+	c.RetryPolicy.MaxDelay = 5 * time.Millisecond
+
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			r := responses[*calls]
+			*calls++
+			if r == nil {
+				return nil, errors.New("connection reset by peer")
+			}
+			return r, nil
+		},
+	}
+	return c
+}
+
+func TestRetryPolicy(t *testing.T) {
+	contactJSON := `{"id": "3100", "properties": {"email": "pp@gmail.com"}}`
+
+	t.Run("retries transient errors on idempotent requests", func(t *testing.T) {
+		calls := 0
+		c := newRetryTestClient([]*http.Response{
+			newMockResponse(http.StatusBadGateway, `<html>Bad Gateway</html>`),
+			nil,
+			newMockResponse(http.StatusOK, contactJSON),
+		}, &calls)
+
+		contact, err := c.ReadContact("pp@gmail.com", "email")
+
+		assert.NoError(t, err, "expected the request to succeed after retrying")
+		assert.Equal(t, "3100", contact.ID)
+		assert.Equal(t, 3, calls, "expected two retries")
+	})
+
+	t.Run("honors Retry-After on rate limited requests", func(t *testing.T) {
+		calls := 0
+		rateLimited := newMockResponse(http.StatusTooManyRequests, `{"status": "error", "category": "RATE_LIMITS"}`)
+		rateLimited.Header.Set("Retry-After", "0")
+		c := newRetryTestClient([]*http.Response{
+			rateLimited,
+			newMockResponse(http.StatusCreated, contactJSON),
+		}, &calls)
+
+		contact, err := c.CreateContact(hubSpot.NewContactInput(map[string]string{}))
+
+		assert.NoError(t, err, "expected the request to succeed after retrying")
+		assert.Equal(t, "3100", contact.ID)
+		assert.Equal(t, 2, calls, "expected one retry")
+	})
+
+	t.Run("does not replay non-idempotent requests", func(t *testing.T) {
+		calls := 0
+		c := newRetryTestClient([]*http.Response{
+			newMockResponse(http.StatusServiceUnavailable, `{"status": "error"}`),
+			newMockResponse(http.StatusCreated, contactJSON),
+		}, &calls)
+
+		_, err := c.CreateContact(hubSpot.NewContactInput(map[string]string{}))
+
+		var apiErr *hubSpot.APIError
+		assert.True(t, errors.As(err, &apiErr), "expected an API error")
+		assert.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+		assert.Equal(t, 1, calls, "expected no retries")
+	})
+
+	t.Run("gives up after MaxRetries", func(t *testing.T) {
+		calls := 0
+		responses := []*http.Response{}
+		for i := 0; i <= hubSpot.DefaultMaxRetries; i++ {
+			responses = append(responses, newMockResponse(http.StatusGatewayTimeout, `{"status": "error"}`))
+		}
+		c := newRetryTestClient(responses, &calls)
+
+		_, err := c.ReadContact("pp@gmail.com", "email")
+
+		var apiErr *hubSpot.APIError
+		assert.True(t, errors.As(err, &apiErr), "expected an API error")
+		assert.Equal(t, http.StatusGatewayTimeout, apiErr.StatusCode)
+		assert.Equal(t, hubSpot.DefaultMaxRetries+1, calls, "expected every retry to be used")
+	})
+}
@@ -0,0 +1,491 @@
+package hubspot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Schema change actions
+const (
+	// SchemaActionCreate creates a group or property missing from the portal
+	SchemaActionCreate = "create"
+	// SchemaActionUpdate updates a group or property that differs from the schema
+	SchemaActionUpdate = "update"
+	// SchemaActionDrift reports a property that differs from the schema but cannot be updated
+	SchemaActionDrift = "drift"
+	// SchemaActionUnmanaged reports a custom property of a managed group missing from the schema
+	SchemaActionUnmanaged = "unmanaged"
+)
+
+// Schema change kinds
+const (
+	SchemaKindGroup    = "group"
+	SchemaKindProperty = "property"
+)
+
+type (
+	// Schema handles the desired property definitions of one or more object types
+	Schema struct {
+		// Objects are the desired groups and properties by object type, e.g. ObjectTypeContacts
+		Objects map[string]ObjectSchema `json:"objects"`
+	}
+
+	// ObjectSchema handles the desired groups and properties of an object type
+	ObjectSchema struct {
+		Groups     []PropertyGroupInput `json:"groups,omitempty"`
+		Properties []PropertySchema     `json:"properties,omitempty"`
+	}
+
+	// PropertySchema handles the desired definition of a property, fields left out of the
+	// schema are left as they are in the portal
+	PropertySchema struct {
+		Name               string           `json:"name"`
+		Label              string           `json:"label"`
+		Type               string           `json:"type"`
+		FieldType          string           `json:"fieldType"`
+		GroupName          string           `json:"groupName"`
+		Description        string           `json:"description,omitempty"`
+		Options            []PropertyOption `json:"options,omitempty"`
+		DisplayOrder       int              `json:"displayOrder,omitempty"`
+		CalculationFormula string           `json:"calculationFormula,omitempty"`
+		HasUniqueValue     *bool            `json:"hasUniqueValue,omitempty"`
+		Hidden             *bool            `json:"hidden,omitempty"`
+		FormField          *bool            `json:"formField,omitempty"`
+		ExternalOptions    *bool            `json:"externalOptions,omitempty"`
+	}
+
+	// SchemaDiff handles a single field of a group or property that differs from the schema
+	SchemaDiff struct {
+		Field   string
+		Current string
+		Desired string
+	}
+
+	// SchemaChange handles a single change of a SchemaPlan
+	SchemaChange struct {
+		ObjectType string
+		// Kind is SchemaKindGroup or SchemaKindProperty
+		Kind string
+		Name string
+		// Action is one of the SchemaAction constants
+		Action string
+		// Diffs are the fields that differ for updates and drift
+		Diffs []SchemaDiff
+		// Reason explains why a drifted property cannot be updated
+		Reason string
+		// Group is the desired definition of a group change
+		Group *PropertyGroupInput
+		// Property is the desired definition of a property change
+		Property *PropertySchema
+		// Err is the reason applying the change failed
+		Err error
+	}
+
+	// SchemaPlan handles the changes needed to reconcile a portal with a Schema
+	SchemaPlan struct {
+		Changes []SchemaChange
+	}
+)
+
+// SchemaError is returned when some changes of a SchemaPlan failed to apply, the error
+// of each failed change is set on its SchemaChange
+type SchemaError struct {
+	Failed int
+	Total  int
+}
+
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("%d of %d schema changes failed", e.Failed, e.Total)
+}
+
+// LoadSchemaFile reads a Schema from a YAML or JSON file, see ParseSchema
+func LoadSchemaFile(path string) (*Schema, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read schema file, err: %w", err)
+	}
+	return ParseSchema(data)
+}
+
+// ParseSchema parses a Schema from YAML or JSON. Fields are named as in HubSpot's properties
+// API, e.g. fieldType and groupName, and option values must be quoted strings in YAML.
+func ParseSchema(data []byte) (*Schema, error) {
+	// YAML is a superset of JSON, decode both as YAML and map the fields by their JSON names
+	var document interface{}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("unable to parse schema, err: %w", err)
+	}
+
+	documentJSON, err := json.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse schema, err: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(documentJSON))
+	decoder.DisallowUnknownFields()
+
+	var schema Schema
+	if err := decoder.Decode(&schema); err != nil {
+		return nil, fmt.Errorf("invalid schema, err: %w", err)
+	}
+	return &schema, nil
+}
+
+// SchemaService reconciles the property definitions of a portal with a Schema
+type SchemaService struct {
+	client *Client
+}
+
+// Schema returns the service reconciling the property definitions of the portal with a Schema
+func (c *Client) Schema() *SchemaService {
+	return &SchemaService{client: c}
+}
+
+// Plan compares the portal with the schema and returns the changes needed to reconcile them,
+// without changing the portal. Print the plan for a dry run.
+func (s *SchemaService) Plan(ctx context.Context, schema *Schema) (*SchemaPlan, error) {
+	plan := &SchemaPlan{}
+	if schema == nil {
+		return plan, nil
+	}
+
+	objectTypes := make([]string, 0, len(schema.Objects))
+	for objectType := range schema.Objects {
+		objectTypes = append(objectTypes, objectType)
+	}
+	sort.Strings(objectTypes)
+
+	for _, objectType := range objectTypes {
+		changes, err := s.planObject(ctx, objectType, schema.Objects[objectType])
+		if err != nil {
+			return nil, err
+		}
+		plan.Changes = append(plan.Changes, changes...)
+	}
+
+	return plan, nil
+}
+
+// Apply creates and updates the groups and properties of the plan, groups first. Drifted and
+// unmanaged properties are only reported. Failed changes do not stop the remaining ones.
+func (s *SchemaService) Apply(ctx context.Context, plan *SchemaPlan) error {
+	if plan == nil {
+		return nil
+	}
+
+	total, failed := 0, 0
+	for _, kind := range []string{SchemaKindGroup, SchemaKindProperty} {
+		for i := range plan.Changes {
+			change := &plan.Changes[i]
+			if change.Kind != kind || !change.Applicable() {
+				continue
+			}
+
+			total++
+			if change.Err = s.apply(ctx, change); change.Err != nil {
+				failed++
+			}
+		}
+	}
+
+	// the definitions cached for validation are stale now
+	if total > 0 && s.client.Validator != nil {
+		s.client.Validator.Invalidate()
+	}
+
+	if failed > 0 {
+		return &SchemaError{Failed: failed, Total: total}
+	}
+	return nil
+}
+
+// planObject returns the changes of an object type
+func (s *SchemaService) planObject(ctx context.Context, objectType string, objectSchema ObjectSchema) ([]SchemaChange, error) {
+	properties := s.client.Properties(objectType)
+
+	currentGroups, err := properties.ListGroups(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list %s property groups, err: %w", objectType, err)
+	}
+	currentProperties, err := properties.List(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list %s properties, err: %w", objectType, err)
+	}
+
+	var changes []SchemaChange
+
+	groupsByName := map[string]PropertyGroup{}
+	for _, group := range currentGroups {
+		groupsByName[group.Name] = group
+	}
+
+	managedGroups := map[string]bool{}
+	for i := range objectSchema.Groups {
+		desired := &objectSchema.Groups[i]
+		managedGroups[desired.Name] = true
+
+		change := SchemaChange{ObjectType: objectType, Kind: SchemaKindGroup, Name: desired.Name, Group: desired}
+		current, ok := groupsByName[desired.Name]
+		if !ok {
+			change.Action = SchemaActionCreate
+			changes = append(changes, change)
+			continue
+		}
+
+		if change.Diffs = diffGroup(&current, desired); len(change.Diffs) > 0 {
+			change.Action = SchemaActionUpdate
+			changes = append(changes, change)
+		}
+	}
+
+	propertiesByName := map[string]Property{}
+	for _, property := range currentProperties {
+		propertiesByName[property.Name] = property
+	}
+
+	desiredNames := map[string]bool{}
+	for i := range objectSchema.Properties {
+		desired := &objectSchema.Properties[i]
+		desiredNames[desired.Name] = true
+		managedGroups[desired.GroupName] = true
+
+		change := SchemaChange{ObjectType: objectType, Kind: SchemaKindProperty, Name: desired.Name, Property: desired}
+		current, ok := propertiesByName[desired.Name]
+		if !ok {
+			change.Action = SchemaActionCreate
+			changes = append(changes, change)
+			continue
+		}
+
+		change.Diffs = diffProperty(&current, desired)
+		if len(change.Diffs) == 0 {
+			continue
+		}
+
+		change.Action = SchemaActionUpdate
+		if change.Reason = propertyDriftReason(&current, change.Diffs); change.Reason != "" {
+			change.Action = SchemaActionDrift
+		}
+		changes = append(changes, change)
+	}
+
+	for _, current := range currentProperties {
+		if !current.HubSpotDefined && managedGroups[current.GroupName] && !desiredNames[current.Name] {
+			changes = append(changes, SchemaChange{
+				ObjectType: objectType,
+				Kind:       SchemaKindProperty,
+				Name:       current.Name,
+				Action:     SchemaActionUnmanaged,
+			})
+		}
+	}
+
+	return changes, nil
+}
+
+// apply creates or updates the group or property of a change
+func (s *SchemaService) apply(ctx context.Context, change *SchemaChange) error {
+	properties := s.client.Properties(change.ObjectType)
+
+	var err error
+	switch {
+	case change.Kind == SchemaKindGroup && change.Action == SchemaActionCreate:
+		_, err = properties.CreateGroup(ctx, change.Group)
+	case change.Kind == SchemaKindGroup:
+		_, err = properties.UpdateGroup(ctx, change.Name, &PropertyGroupUpdateInput{
+			Label:        change.Group.Label,
+			DisplayOrder: optionalInt(change.Group.DisplayOrder),
+		})
+	case change.Action == SchemaActionCreate:
+		_, err = properties.Create(ctx, change.Property.input())
+	default:
+		_, err = properties.Update(ctx, change.Name, propertyUpdateInput(change.Property))
+	}
+
+	if err != nil {
+		return fmt.Errorf("unable to %s %s %s %s, err: %w", change.Action, change.ObjectType, change.Kind, change.Name, err)
+	}
+	return nil
+}
+
+// Applicable reports whether Apply changes the portal for the change
+func (c *SchemaChange) Applicable() bool {
+	return c.Action == SchemaActionCreate || c.Action == SchemaActionUpdate
+}
+
+// HasChanges reports whether applying the plan changes the portal
+func (p *SchemaPlan) HasChanges() bool {
+	for i := range p.Changes {
+		if p.Changes[i].Applicable() {
+			return true
+		}
+	}
+	return false
+}
+
+// String formats the plan for a dry run, one line per change followed by the differing fields
+func (p *SchemaPlan) String() string {
+	var b strings.Builder
+	counts := map[string]int{}
+
+	for _, change := range p.Changes {
+		counts[change.Action]++
+
+		fmt.Fprintf(&b, "%s %s %s %s", change.Action, change.ObjectType, change.Kind, change.Name)
+		if change.Reason != "" {
+			fmt.Fprintf(&b, " (%s)", change.Reason)
+		}
+		if change.Err != nil {
+			fmt.Fprintf(&b, " failed: %s", change.Err)
+		}
+		b.WriteString("\n")
+
+		for _, diff := range change.Diffs {
+			fmt.Fprintf(&b, "    %s: %q => %q\n", diff.Field, diff.Current, diff.Desired)
+		}
+	}
+
+	fmt.Fprintf(&b, "Plan: %d to create, %d to update, %d drifted, %d unmanaged\n",
+		counts[SchemaActionCreate], counts[SchemaActionUpdate], counts[SchemaActionDrift], counts[SchemaActionUnmanaged])
+	return b.String()
+}
+
+// diffGroup returns the fields of a group that differ from its desired definition
+func diffGroup(current *PropertyGroup, desired *PropertyGroupInput) []SchemaDiff {
+	var diffs []SchemaDiff
+	diffs = appendDiff(diffs, "label", current.Label, desired.Label)
+	if desired.DisplayOrder != 0 {
+		diffs = appendDiff(diffs, "displayOrder", strconv.Itoa(current.DisplayOrder), strconv.Itoa(desired.DisplayOrder))
+	}
+	return diffs
+}
+
+// diffProperty returns the fields of a property that differ from its desired definition,
+// optional fields are only compared when the schema sets them, as an update cannot clear them
+func diffProperty(current *Property, desired *PropertySchema) []SchemaDiff {
+	var diffs []SchemaDiff
+	diffs = appendDiff(diffs, "label", current.Label, desired.Label)
+	diffs = appendDiff(diffs, "type", current.Type, desired.Type)
+	diffs = appendDiff(diffs, "fieldType", current.FieldType, desired.FieldType)
+	diffs = appendDiff(diffs, "groupName", current.GroupName, desired.GroupName)
+	if desired.Description != "" {
+		diffs = appendDiff(diffs, "description", current.Description, desired.Description)
+	}
+	if desired.CalculationFormula != "" {
+		diffs = appendDiff(diffs, "calculationFormula", current.CalculationFormula, desired.CalculationFormula)
+	}
+	if len(desired.Options) > 0 {
+		diffs = appendDiff(diffs, "options", formatOptions(current.Options), formatOptions(desired.Options))
+	}
+	if desired.Hidden != nil {
+		diffs = appendDiff(diffs, "hidden", strconv.FormatBool(current.Hidden), strconv.FormatBool(*desired.Hidden))
+	}
+	if desired.FormField != nil {
+		diffs = appendDiff(diffs, "formField", strconv.FormatBool(current.FormField), strconv.FormatBool(*desired.FormField))
+	}
+	if desired.HasUniqueValue != nil {
+		diffs = appendDiff(diffs, "hasUniqueValue",
+			strconv.FormatBool(current.HasUniqueValue), strconv.FormatBool(*desired.HasUniqueValue))
+	}
+	if desired.DisplayOrder != 0 {
+		diffs = appendDiff(diffs, "displayOrder", strconv.Itoa(current.DisplayOrder), strconv.Itoa(desired.DisplayOrder))
+	}
+	return diffs
+}
+
+// propertyDriftReason returns why a property differing from the schema cannot be updated,
+// or an empty string when it can
+func propertyDriftReason(current *Property, diffs []SchemaDiff) string {
+	switch {
+	case current.HubSpotDefined:
+		return "defined by HubSpot"
+	case current.ModificationMetadata != nil && current.ModificationMetadata.ReadOnlyDefinition:
+		return "read-only definition"
+	}
+	for _, diff := range diffs {
+		if diff.Field == "hasUniqueValue" {
+			return "hasUniqueValue cannot be changed"
+		}
+	}
+	return ""
+}
+
+// appendDiff appends a diff when the current and desired values differ
+func appendDiff(diffs []SchemaDiff, field string, current string, desired string) []SchemaDiff {
+	if current == desired {
+		return diffs
+	}
+	return append(diffs, SchemaDiff{Field: field, Current: current, Desired: desired})
+}
+
+// formatOptions formats enumeration options in display order for comparison
+func formatOptions(options []PropertyOption) string {
+	sorted := append([]PropertyOption{}, options...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].DisplayOrder < sorted[j].DisplayOrder
+	})
+
+	formatted := make([]string, len(sorted))
+	for i, option := range sorted {
+		formatted[i] = fmt.Sprintf("%s=%s", option.Value, option.Label)
+		if option.Description != "" {
+			formatted[i] += fmt.Sprintf(" (%s)", option.Description)
+		}
+		if option.Hidden {
+			formatted[i] += " hidden"
+		}
+	}
+	return strings.Join(formatted, "; ")
+}
+
+// input converts a desired property definition to a property input, unset flags are false
+func (p *PropertySchema) input() *PropertyInput {
+	return &PropertyInput{
+		Name:               p.Name,
+		Label:              p.Label,
+		Type:               p.Type,
+		FieldType:          p.FieldType,
+		GroupName:          p.GroupName,
+		Description:        p.Description,
+		Options:            p.Options,
+		DisplayOrder:       p.DisplayOrder,
+		CalculationFormula: p.CalculationFormula,
+		HasUniqueValue:     p.HasUniqueValue != nil && *p.HasUniqueValue,
+		Hidden:             p.Hidden != nil && *p.Hidden,
+		FormField:          p.FormField != nil && *p.FormField,
+		ExternalOptions:    p.ExternalOptions != nil && *p.ExternalOptions,
+	}
+}
+
+// propertyUpdateInput converts a desired property definition to a property update,
+// leaving the flags the schema does not set unchanged
+func propertyUpdateInput(desired *PropertySchema) *PropertyUpdateInput {
+	return &PropertyUpdateInput{
+		Label:              desired.Label,
+		Type:               desired.Type,
+		FieldType:          desired.FieldType,
+		GroupName:          desired.GroupName,
+		Description:        desired.Description,
+		CalculationFormula: desired.CalculationFormula,
+		Options:            desired.Options,
+		DisplayOrder:       optionalInt(desired.DisplayOrder),
+		Hidden:             desired.Hidden,
+		FormField:          desired.FormField,
+	}
+}
+
+// optionalInt returns nil for zero, leaving the value unchanged in an update
+func optionalInt(value int) *int {
+	if value == 0 {
+		return nil
+	}
+	return &value
+}
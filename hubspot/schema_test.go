@@ -0,0 +1,218 @@
+package hubspot_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	hubSpot "github.com/teamexos/hubspot-api-go/hubspot"
+)
+
+const testSchemaYAML = `
+objects:
+  contacts:
+    groups:
+      - name: exos
+        label: EXOS
+      - name: exos_coaching
+        label: EXOS coaching
+    properties:
+      - name: exos_perform_account_verified
+        label: EXOS Perform account verified
+        type: enumeration
+        fieldType: booleancheckbox
+        groupName: exos
+        formField: true
+        options:
+          - {label: "Yes", value: "true"}
+          - {label: "No", value: "false", displayOrder: 1}
+      - name: exos_membership_tier
+        label: Membership tier
+        type: enumeration
+        fieldType: select
+        groupName: exos
+        options:
+          - {label: Gold, value: gold}
+          - {label: Silver, value: silver, displayOrder: 1}
+      - name: exos_coach
+        label: Coach
+        type: string
+        fieldType: text
+        groupName: exos_coaching
+      - name: firstname
+        label: Given name
+        type: string
+        fieldType: text
+        groupName: contactinformation
+`
+
+func newSchemaTestClient(t *testing.T, gotRequests *[]string, gotBodies map[string]map[string]interface{}) *hubSpot.Client {
+	c := hubSpot.NewClient("fake-api-key")
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			request := req.Method + " " + req.URL.Path
+			*gotRequests = append(*gotRequests, request)
+
+			if req.Body != nil && req.Method != http.MethodGet {
+				body, _ := ioutil.ReadAll(req.Body)
+				var decoded map[string]interface{}
+				assert.NoError(t, json.Unmarshal(body, &decoded))
+				gotBodies[request] = decoded
+			}
+
+			switch request {
+			case "GET /crm/v3/properties/contacts/groups":
+				return newMockResponse(http.StatusOK, `{"results": [
+					{"name": "contactinformation", "label": "Contact information", "displayOrder": -1},
+					{"name": "exos", "label": "EXOS", "displayOrder": 5}
+				]}`), nil
+			case "GET /crm/v3/properties/contacts":
+				return newMockResponse(http.StatusOK, `{"results": [
+					`+verifiedPropertyJSON+`,
+					{
+						"name": "exos_membership_tier", "label": "Tier", "type": "enumeration", "fieldType": "select",
+						"groupName": "exos", "options": [{"label": "Gold", "value": "gold", "displayOrder": 0}]
+					},
+					{"name": "exos_legacy_id", "label": "Legacy ID", "type": "string", "fieldType": "text", "groupName": "exos"},
+					{
+						"name": "firstname", "label": "First Name", "type": "string", "fieldType": "text",
+						"groupName": "contactinformation", "hubspotDefined": true
+					}
+				]}`), nil
+			case "POST /crm/v3/properties/contacts/groups":
+				return newMockResponse(http.StatusCreated, `{"name": "exos_coaching", "label": "EXOS coaching"}`), nil
+			case "POST /crm/v3/properties/contacts":
+				return newMockResponse(http.StatusCreated, `{"name": "exos_coach"}`), nil
+			case "PATCH /crm/v3/properties/contacts/exos_membership_tier":
+				return newMockResponse(http.StatusBadRequest, `{"status": "error", "category": "VALIDATION_ERROR"}`), nil
+			}
+
+			t.Errorf("unexpected request %s", request)
+			return newMockResponse(http.StatusNotFound, `{}`), nil
+		},
+	}
+	return c
+}
+
+func TestParseSchema(t *testing.T) {
+	schema, err := hubSpot.ParseSchema([]byte(testSchemaYAML))
+
+	assert.NoError(t, err)
+	contacts := schema.Objects[hubSpot.ObjectTypeContacts]
+	assert.Len(t, contacts.Groups, 2)
+	assert.Len(t, contacts.Properties, 4)
+	assert.Equal(t, "booleancheckbox", contacts.Properties[0].FieldType)
+	assert.Equal(t, 1, contacts.Properties[0].Options[1].DisplayOrder)
+
+	schema, err = hubSpot.ParseSchema([]byte(`{"objects": {"deals": {"properties": [{"name": "exos_seats", "type": "number"}]}}}`))
+	assert.NoError(t, err, "expected JSON to be parsed")
+	assert.Equal(t, "number", schema.Objects[hubSpot.ObjectTypeDeals].Properties[0].Type)
+
+	_, err = hubSpot.ParseSchema([]byte(`{"objects": {"deals": {"properties": [{"name": "exos_seats", "kind": "number"}]}}}`))
+	assert.Error(t, err, "expected unknown fields to be rejected")
+}
+
+func TestSchemaPlanAndApply(t *testing.T) {
+	var gotRequests []string
+	gotBodies := map[string]map[string]interface{}{}
+	c := newSchemaTestClient(t, &gotRequests, gotBodies)
+
+	schema, err := hubSpot.ParseSchema([]byte(testSchemaYAML))
+	assert.NoError(t, err)
+
+	plan, err := c.Schema().Plan(context.Background(), schema)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"GET /crm/v3/properties/contacts/groups",
+		"GET /crm/v3/properties/contacts",
+	}, gotRequests, "expected the plan not to change the portal")
+	assert.True(t, plan.HasChanges())
+	assert.Equal(t, `create contacts group exos_coaching
+update contacts property exos_membership_tier
+    label: "Tier" => "Membership tier"
+    options: "gold=Gold" => "gold=Gold; silver=Silver"
+create contacts property exos_coach
+drift contacts property firstname (defined by HubSpot)
+    label: "First Name" => "Given name"
+unmanaged contacts property exos_legacy_id
+Plan: 2 to create, 1 to update, 1 drifted, 1 unmanaged
+`, plan.String())
+
+	gotRequests = nil
+	err = c.Schema().Apply(context.Background(), plan)
+
+	var schemaErr *hubSpot.SchemaError
+	assert.True(t, errors.As(err, &schemaErr), "expected a schema error")
+	assert.Equal(t, 1, schemaErr.Failed)
+	assert.Equal(t, 3, schemaErr.Total)
+	assert.Equal(t, []string{
+		"POST /crm/v3/properties/contacts/groups",
+		"PATCH /crm/v3/properties/contacts/exos_membership_tier",
+		"POST /crm/v3/properties/contacts",
+	}, gotRequests, "expected groups to be applied first and drift to be skipped")
+	assert.True(t, errors.Is(plan.Changes[1].Err, hubSpot.ErrBadRequest))
+	assert.Equal(t, "Membership tier", gotBodies["PATCH /crm/v3/properties/contacts/exos_membership_tier"]["label"])
+	assert.Equal(t, "exos_coach", gotBodies["POST /crm/v3/properties/contacts"]["name"])
+}
+
+func TestSchemaPlanAfterApply(t *testing.T) {
+	portal := map[string]interface{}{
+		"name": "exos_coach", "label": "Coach", "type": "string", "fieldType": "text", "groupName": "exos",
+		"description": "Set by the coaching team", "calculationFormula": "", "options": []interface{}{},
+		"formField": true, "hasUniqueValue": true,
+	}
+
+	c := hubSpot.NewClient("fake-api-key")
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			switch req.Method + " " + req.URL.Path {
+			case "GET /crm/v3/properties/contacts/groups":
+				return newMockResponse(http.StatusOK, `{"results": [{"name": "exos", "label": "EXOS"}]}`), nil
+			case "GET /crm/v3/properties/contacts":
+				property, _ := json.Marshal(portal)
+				return newMockResponse(http.StatusOK, `{"results": [`+string(property)+`]}`), nil
+			case "PATCH /crm/v3/properties/contacts/exos_coach":
+				body, _ := ioutil.ReadAll(req.Body)
+				var update map[string]interface{}
+				assert.NoError(t, json.Unmarshal(body, &update))
+				for field, value := range update {
+					portal[field] = value
+				}
+				property, _ := json.Marshal(portal)
+				return newMockResponse(http.StatusOK, string(property)), nil
+			}
+
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+			return newMockResponse(http.StatusNotFound, `{}`), nil
+		},
+	}
+
+	schema, err := hubSpot.ParseSchema([]byte(`
+objects:
+  contacts:
+    groups:
+      - {name: exos, label: EXOS}
+    properties:
+      - {name: exos_coach, label: Assigned coach, type: string, fieldType: text, groupName: exos}
+`))
+	assert.NoError(t, err)
+
+	plan, err := c.Schema().Plan(context.Background(), schema)
+	assert.NoError(t, err)
+	assert.Equal(t, `update contacts property exos_coach
+    label: "Coach" => "Assigned coach"
+Plan: 0 to create, 1 to update, 0 drifted, 0 unmanaged
+`, plan.String(), "expected fields only set in the portal not to be diffed")
+
+	assert.NoError(t, c.Schema().Apply(context.Background(), plan))
+	assert.Equal(t, true, portal["formField"], "expected a flag left out of the schema to be left unchanged")
+
+	plan, err = c.Schema().Plan(context.Background(), schema)
+	assert.NoError(t, err)
+	assert.False(t, plan.HasChanges(), "expected no changes after the update was applied, got:\n%s", plan)
+}
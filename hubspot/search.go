@@ -0,0 +1,233 @@
+package hubspot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Search filter operators
+const (
+	OperatorEQ               = "EQ"
+	OperatorNEQ              = "NEQ"
+	OperatorLT               = "LT"
+	OperatorLTE              = "LTE"
+	OperatorGT               = "GT"
+	OperatorGTE              = "GTE"
+	OperatorBetween          = "BETWEEN"
+	OperatorIn               = "IN"
+	OperatorNotIn            = "NOT_IN"
+	OperatorHasProperty      = "HAS_PROPERTY"
+	OperatorNotHasProperty   = "NOT_HAS_PROPERTY"
+	OperatorContainsToken    = "CONTAINS_TOKEN"
+	OperatorNotContainsToken = "NOT_CONTAINS_TOKEN"
+)
+
+// Search sort directions
+const (
+	SortAscending  = "ASCENDING"
+	SortDescending = "DESCENDING"
+)
+
+// SearchResultLimit is the maximum number of results HubSpot returns for a single search,
+// paging beyond it fails
+const SearchResultLimit = 10000
+
+// defaultSearchPageSize is the page size HubSpot uses when a search sets no limit
+const defaultSearchPageSize = 10
+
+// ErrSearchResultLimit is returned when a search pages beyond SearchResultLimit results,
+// narrow the filters (e.g. by a lastmodifieddate range) to reach the remaining results
+var ErrSearchResultLimit = errors.New("search cannot page beyond 10000 results")
+
+type (
+	// SearchRequest handles the body of a CRM search
+	SearchRequest struct {
+		FilterGroups []FilterGroup `json:"filterGroups,omitempty"`
+		Sorts        []Sort        `json:"sorts,omitempty"`
+		Query        string        `json:"query,omitempty"`
+		Properties   []string      `json:"properties,omitempty"`
+		Limit        int           `json:"limit,omitempty"`
+		After        string        `json:"after,omitempty"`
+	}
+
+	// FilterGroup handles filters that must all match, groups are combined with OR
+	FilterGroup struct {
+		Filters []Filter `json:"filters"`
+	}
+
+	// Filter handles a single search condition on a property
+	Filter struct {
+		PropertyName string   `json:"propertyName"`
+		Operator     string   `json:"operator"`
+		Value        string   `json:"value,omitempty"`
+		HighValue    string   `json:"highValue,omitempty"`
+		Values       []string `json:"values,omitempty"`
+	}
+
+	// Sort handles the ordering of search results by a property
+	Sort struct {
+		PropertyName string `json:"propertyName"`
+		Direction    string `json:"direction"`
+	}
+
+	// SearchResults handles a page of search results
+	SearchResults struct {
+		Total   int            `json:"total"`
+		Results []ObjectOutput `json:"results"`
+		Paging  *Paging        `json:"paging"`
+	}
+)
+
+// SearchBuilder builds a SearchRequest. Filters are added to the current filter group,
+// all of which must match, and Or starts a new filter group.
+type SearchBuilder struct {
+	request SearchRequest
+}
+
+// NewSearch creates a new SearchBuilder
+func NewSearch() *SearchBuilder {
+	return &SearchBuilder{}
+}
+
+// Where adds a filter with any operator to the current filter group
+func (b *SearchBuilder) Where(propertyName string, operator string, value string) *SearchBuilder {
+	return b.filter(Filter{PropertyName: propertyName, Operator: operator, Value: value})
+}
+
+// Equal matches objects whose property equals value
+func (b *SearchBuilder) Equal(propertyName string, value string) *SearchBuilder {
+	return b.Where(propertyName, OperatorEQ, value)
+}
+
+// NotEqual matches objects whose property does not equal value
+func (b *SearchBuilder) NotEqual(propertyName string, value string) *SearchBuilder {
+	return b.Where(propertyName, OperatorNEQ, value)
+}
+
+// GreaterThan matches objects whose property is greater than value
+func (b *SearchBuilder) GreaterThan(propertyName string, value string) *SearchBuilder {
+	return b.Where(propertyName, OperatorGT, value)
+}
+
+// LessThan matches objects whose property is less than value
+func (b *SearchBuilder) LessThan(propertyName string, value string) *SearchBuilder {
+	return b.Where(propertyName, OperatorLT, value)
+}
+
+// Between matches objects whose property is between low and high, inclusive
+func (b *SearchBuilder) Between(propertyName string, low string, high string) *SearchBuilder {
+	return b.filter(Filter{PropertyName: propertyName, Operator: OperatorBetween, Value: low, HighValue: high})
+}
+
+// In matches objects whose property equals one of values
+func (b *SearchBuilder) In(propertyName string, values ...string) *SearchBuilder {
+	return b.filter(Filter{PropertyName: propertyName, Operator: OperatorIn, Values: values})
+}
+
+// HasProperty matches objects with a value for the property
+func (b *SearchBuilder) HasProperty(propertyName string) *SearchBuilder {
+	return b.filter(Filter{PropertyName: propertyName, Operator: OperatorHasProperty})
+}
+
+// ContainsToken matches objects whose property contains the token, * is a wildcard
+func (b *SearchBuilder) ContainsToken(propertyName string, token string) *SearchBuilder {
+	return b.Where(propertyName, OperatorContainsToken, token)
+}
+
+// Or starts a new filter group, an object matches when all filters of any group match
+func (b *SearchBuilder) Or() *SearchBuilder {
+	b.request.FilterGroups = append(b.request.FilterGroups, FilterGroup{})
+	return b
+}
+
+// Query matches objects with a default searchable property containing the text
+func (b *SearchBuilder) Query(query string) *SearchBuilder {
+	b.request.Query = query
+	return b
+}
+
+// SortBy orders the results by a property, direction is SortAscending or SortDescending
+func (b *SearchBuilder) SortBy(propertyName string, direction string) *SearchBuilder {
+	b.request.Sorts = append(b.request.Sorts, Sort{PropertyName: propertyName, Direction: direction})
+	return b
+}
+
+// Properties sets the properties returned with every result
+func (b *SearchBuilder) Properties(properties ...string) *SearchBuilder {
+	b.request.Properties = append(b.request.Properties, properties...)
+	return b
+}
+
+// Limit sets the page size
+func (b *SearchBuilder) Limit(limit int) *SearchBuilder {
+	b.request.Limit = limit
+	return b
+}
+
+// After sets the paging cursor returned with the previous page
+func (b *SearchBuilder) After(after string) *SearchBuilder {
+	b.request.After = after
+	return b
+}
+
+// Build returns the SearchRequest
+func (b *SearchBuilder) Build() *SearchRequest {
+	request := b.request
+
+	// drop groups left empty by a trailing Or
+	request.FilterGroups = nil
+	for _, group := range b.request.FilterGroups {
+		if len(group.Filters) > 0 {
+			request.FilterGroups = append(request.FilterGroups, group)
+		}
+	}
+	return &request
+}
+
+// filter adds a filter to the current filter group
+func (b *SearchBuilder) filter(filter Filter) *SearchBuilder {
+	if len(b.request.FilterGroups) == 0 {
+		b.request.FilterGroups = append(b.request.FilterGroups, FilterGroup{})
+	}
+	group := &b.request.FilterGroups[len(b.request.FilterGroups)-1]
+	group.Filters = append(group.Filters, filter)
+	return b
+}
+
+// SearchTimeValue formats t as a filter value for date and datetime properties
+func SearchTimeValue(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano()/int64(time.Millisecond), 10)
+}
+
+// Search gets a page of objects of an object type matching the search request
+func (c *Client) Search(objectType string, search *SearchRequest) (*SearchResults, error) {
+	return c.SearchWithContext(context.Background(), objectType, search)
+}
+
+// SearchWithContext gets a page of objects of an object type matching the search request using the given context
+func (c *Client) SearchWithContext(ctx context.Context, objectType string, search *SearchRequest) (*SearchResults, error) {
+	return c.Objects(objectType).Search(ctx, search)
+}
+
+// checkSearchLimit makes sure a search does not page beyond SearchResultLimit
+func checkSearchLimit(search *SearchRequest) error {
+	if search == nil || search.After == "" {
+		return nil
+	}
+
+	after, err := strconv.Atoi(search.After)
+	if err != nil {
+		return nil
+	}
+	limit := search.Limit
+	if limit <= 0 {
+		limit = defaultSearchPageSize
+	}
+	if after+limit > SearchResultLimit {
+		return fmt.Errorf("after %d with limit %d: %w", after, limit, ErrSearchResultLimit)
+	}
+	return nil
+}
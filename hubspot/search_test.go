@@ -0,0 +1,75 @@
+package hubspot_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	hubSpot "github.com/teamexos/hubspot-api-go/hubspot"
+)
+
+func TestSearchBuilder(t *testing.T) {
+	since := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	search := hubSpot.NewSearch().
+		Equal("company", "Marvel").
+		GreaterThan("lastmodifieddate", hubSpot.SearchTimeValue(since)).
+		Or().
+		In("lifecyclestage", "lead", "customer").
+		Between("num_employees", "10", "50").
+		Or().
+		SortBy("lastmodifieddate", hubSpot.SortDescending).
+		Properties("email", "firstname").
+		Limit(50).
+		Build()
+
+	assert.Len(t, search.FilterGroups, 2, "expected the trailing empty group to be dropped")
+	assert.Equal(t, []hubSpot.Filter{
+		{PropertyName: "company", Operator: hubSpot.OperatorEQ, Value: "Marvel"},
+		{PropertyName: "lastmodifieddate", Operator: hubSpot.OperatorGT, Value: "1609459200000"},
+	}, search.FilterGroups[0].Filters)
+	assert.Equal(t, []hubSpot.Filter{
+		{PropertyName: "lifecyclestage", Operator: hubSpot.OperatorIn, Values: []string{"lead", "customer"}},
+		{PropertyName: "num_employees", Operator: hubSpot.OperatorBetween, Value: "10", HighValue: "50"},
+	}, search.FilterGroups[1].Filters)
+	assert.Equal(t, []hubSpot.Sort{{PropertyName: "lastmodifieddate", Direction: hubSpot.SortDescending}}, search.Sorts)
+	assert.Equal(t, []string{"email", "firstname"}, search.Properties)
+	assert.Equal(t, 50, search.Limit)
+}
+
+func TestSearch(t *testing.T) {
+	c := hubSpot.NewClient("fake-api-key")
+
+	var gotBody hubSpot.SearchRequest
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "/crm/v3/objects/contacts/search", req.URL.Path)
+			body, _ := ioutil.ReadAll(req.Body)
+			assert.NoError(t, json.Unmarshal(body, &gotBody))
+			return newMockResponse(http.StatusOK, `{
+				"total": 12000,
+				"results": [{"id": "3100", "properties": {"email": "pp@gmail.com"}}],
+				"paging": {"next": {"after": "9999"}}
+			}`), nil
+		},
+	}
+
+	results, err := c.Search(hubSpot.ObjectTypeContacts, hubSpot.NewSearch().Equal("company", "Marvel").After("9998").Limit(1).Build())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Marvel", gotBody.FilterGroups[0].Filters[0].Value)
+	assert.Equal(t, 12000, results.Total)
+	assert.Equal(t, "9999", results.Paging.NextAfter())
+
+	_, err = c.Search(hubSpot.ObjectTypeContacts, hubSpot.NewSearch().After("10000").Build())
+
+	assert.True(t, errors.Is(err, hubSpot.ErrSearchResultLimit), "expected the result cap as an error")
+
+	_, err = c.Search(hubSpot.ObjectTypeContacts, hubSpot.NewSearch().After("9950").Limit(100).Build())
+
+	assert.True(t, errors.Is(err, hubSpot.ErrSearchResultLimit), "expected a page ending beyond the result cap as an error")
+}
@@ -0,0 +1,189 @@
+package hubspot
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Ticket object type and properties
+const (
+	ObjectTypeTickets = "tickets"
+
+	TicketPropertySubject       = "subject"
+	TicketPropertyContent       = "content"
+	TicketPropertyPipeline      = "hs_pipeline"
+	TicketPropertyPipelineStage = "hs_pipeline_stage"
+	TicketPropertyPriority      = "hs_ticket_priority"
+)
+
+// DefaultTicketPipeline is the ID of the support pipeline every HubSpot account starts with
+const DefaultTicketPipeline = "0"
+
+// Ticket stages of the DefaultTicketPipeline
+const (
+	TicketStageNew              = "1"
+	TicketStageWaitingOnContact = "2"
+	TicketStageWaitingOnUs      = "3"
+	TicketStageClosed           = "4"
+)
+
+// Ticket priorities
+const (
+	TicketPriorityLow    = "LOW"
+	TicketPriorityMedium = "MEDIUM"
+	TicketPriorityHigh   = "HIGH"
+)
+
+// TicketInput handles a ticket body representation from HubSpot
+type TicketInput struct {
+	Properties map[string]string `json:"properties"`
+}
+
+// TicketOutput handles a ticket representation from HubSpot
+type TicketOutput struct {
+	ID         string            `json:"id"`
+	Properties map[string]string `json:"properties"`
+	CreatedAt  time.Time         `json:"createdAt"`
+	UpdatedAt  time.Time         `json:"updatedAt"`
+	Archived   bool              `json:"archived"`
+	ArchivedAt time.Time         `json:"archivedAt"`
+}
+
+// NewTicketInput creates a new Ticket Body representation
+func NewTicketInput(properties map[string]string) *TicketInput {
+	if properties == nil {
+		properties = map[string]string{}
+	}
+	return &TicketInput{
+		Properties: properties,
+	}
+}
+
+// SetSubject sets the ticket name
+func (t *TicketInput) SetSubject(subject string) *TicketInput {
+	return t.set(TicketPropertySubject, subject)
+}
+
+// SetContent sets the ticket description
+func (t *TicketInput) SetContent(content string) *TicketInput {
+	return t.set(TicketPropertyContent, content)
+}
+
+// SetPipeline sets the ID of the pipeline the ticket belongs to
+func (t *TicketInput) SetPipeline(pipelineID string) *TicketInput {
+	return t.set(TicketPropertyPipeline, pipelineID)
+}
+
+// SetPipelineStage sets the ID of the ticket status within the ticket pipeline
+func (t *TicketInput) SetPipelineStage(stageID string) *TicketInput {
+	return t.set(TicketPropertyPipelineStage, stageID)
+}
+
+// SetPriority sets the ticket priority, one of the TicketPriority values
+func (t *TicketInput) SetPriority(priority string) *TicketInput {
+	return t.set(TicketPropertyPriority, priority)
+}
+
+// set sets a property, creating the properties map when needed
+func (t *TicketInput) set(property string, value string) *TicketInput {
+	if t.Properties == nil {
+		t.Properties = map[string]string{}
+	}
+	t.Properties[property] = value
+	return t
+}
+
+// Pipeline returns the ID of the pipeline the ticket belongs to
+func (t *TicketOutput) Pipeline() string {
+	return t.Properties[TicketPropertyPipeline]
+}
+
+// PipelineStage returns the ID of the ticket status within the ticket pipeline
+func (t *TicketOutput) PipelineStage() string {
+	return t.Properties[TicketPropertyPipelineStage]
+}
+
+// CreateTicket creates a new Ticket in HubSpot
+func (c *Client) CreateTicket(ticketInput *TicketInput) (*TicketOutput, error) {
+	return c.CreateTicketWithContext(context.Background(), ticketInput)
+}
+
+// CreateTicketWithContext creates a new Ticket in HubSpot using the given context
+func (c *Client) CreateTicketWithContext(ctx context.Context, ticketInput *TicketInput) (*TicketOutput, error) {
+	apiURL := c.buildURL(c.objectPath(ObjectTypeTickets, ""), nil)
+
+	var ticketOutput TicketOutput
+	if err := c.do(ctx, http.MethodPost, apiURL, ticketInput, http.StatusCreated, &ticketOutput); err != nil {
+		return nil, err
+	}
+
+	return &ticketOutput, nil
+}
+
+// ReadTicket gets a Ticket in HubSpot
+// properties is a comma separated string (no spaces!) of the properties (subject,content,..) to be returned in the response
+func (c *Client) ReadTicket(ticketID string, properties string) (*TicketOutput, error) {
+	return c.ReadTicketWithContext(context.Background(), ticketID, properties)
+}
+
+// ReadTicketWithContext gets a Ticket in HubSpot using the given context
+func (c *Client) ReadTicketWithContext(ctx context.Context, ticketID string, properties string) (*TicketOutput, error) {
+	if err := checkObjectID("ticket", ticketID); err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	if properties != "" {
+		query.Set("properties", properties)
+	}
+	apiURL := c.buildURL(c.objectPath(ObjectTypeTickets, ticketID), query)
+
+	var ticketOutput TicketOutput
+	if err := c.do(ctx, http.MethodGet, apiURL, nil, http.StatusOK, &ticketOutput); err != nil {
+		return nil, err
+	}
+
+	return &ticketOutput, nil
+}
+
+// UpdateTicket updates a Ticket in HubSpot
+func (c *Client) UpdateTicket(ticketID string, ticketInput *TicketInput) (*TicketOutput, error) {
+	return c.UpdateTicketWithContext(context.Background(), ticketID, ticketInput)
+}
+
+// UpdateTicketWithContext updates a Ticket in HubSpot using the given context
+func (c *Client) UpdateTicketWithContext(
+	ctx context.Context,
+	ticketID string,
+	ticketInput *TicketInput) (*TicketOutput, error) {
+
+	if err := checkObjectID("ticket", ticketID); err != nil {
+		return nil, err
+	}
+
+	apiURL := c.buildURL(c.objectPath(ObjectTypeTickets, ticketID), nil)
+
+	var ticketOutput TicketOutput
+	if err := c.do(ctx, http.MethodPatch, apiURL, ticketInput, http.StatusOK, &ticketOutput); err != nil {
+		return nil, err
+	}
+
+	return &ticketOutput, nil
+}
+
+// ArchiveTicket moves a Ticket in HubSpot to the recycling bin
+func (c *Client) ArchiveTicket(ticketID string) error {
+	return c.ArchiveTicketWithContext(context.Background(), ticketID)
+}
+
+// ArchiveTicketWithContext moves a Ticket in HubSpot to the recycling bin using the given context
+func (c *Client) ArchiveTicketWithContext(ctx context.Context, ticketID string) error {
+	if err := checkObjectID("ticket", ticketID); err != nil {
+		return err
+	}
+
+	apiURL := c.buildURL(c.objectPath(ObjectTypeTickets, ticketID), nil)
+	return c.do(ctx, http.MethodDelete, apiURL, nil, http.StatusNoContent, nil)
+}
@@ -0,0 +1,166 @@
+package hubspot_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	hubSpot "github.com/teamexos/hubspot-api-go/hubspot"
+)
+
+func TestCreateTicket(t *testing.T) {
+	c := hubSpot.NewClient("this-Is-A-Secret-!")
+
+	var gotBody hubSpot.TicketInput
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "/crm/v3/objects/tickets", req.URL.Path)
+			body, _ := ioutil.ReadAll(req.Body)
+			assert.NoError(t, json.Unmarshal(body, &gotBody))
+			return newMockResponse(http.StatusCreated, `{
+				"id": "7714",
+				"properties": {
+					"content": "Unable to sign in to the app",
+					"hs_pipeline": "0",
+					"hs_pipeline_stage": "1",
+					"hs_ticket_priority": "HIGH",
+					"subject": "Sign in escalation"
+				},
+				"createdAt": "2021-01-12T15:47:54.554Z",
+				"updatedAt": "2021-01-12T15:47:54.554Z",
+				"archived": false
+			}`), nil
+		},
+	}
+
+	ticketInput := hubSpot.NewTicketInput(nil).
+		SetSubject("Sign in escalation").
+		SetContent("Unable to sign in to the app").
+		SetPipeline(hubSpot.DefaultTicketPipeline).
+		SetPipelineStage(hubSpot.TicketStageNew).
+		SetPriority(hubSpot.TicketPriorityHigh)
+
+	ticket, err := c.CreateTicket(ticketInput)
+
+	assert.NoError(t, err, "expected empty error response")
+	assert.Equal(t, "0", gotBody.Properties["hs_pipeline"])
+	assert.Equal(t, "1", gotBody.Properties["hs_pipeline_stage"])
+	assert.Equal(t, "HIGH", gotBody.Properties["hs_ticket_priority"])
+	assert.Equal(t, "7714", ticket.ID)
+	assert.Equal(t, hubSpot.DefaultTicketPipeline, ticket.Pipeline())
+	assert.Equal(t, hubSpot.TicketStageNew, ticket.PipelineStage())
+
+	association := hubSpot.NewSingleTicketToContactAssociationInput(ticket.ID, "3051")
+	assert.Equal(t, hubSpot.AssociationTicketToContact, association.Inputs[0].AssociationType)
+	assert.Equal(t, "7714", association.Inputs[0].From.ID)
+}
+
+func TestReadTicket(t *testing.T) {
+	c := hubSpot.NewClient("fake-api-key")
+
+	tests := []struct {
+		name           string
+		id             string
+		wantPath       string
+		wantStatusCode int
+		wantErrorMsg   string
+	}{
+		{
+			name:           "by id",
+			id:             "7714",
+			wantPath:       "/crm/v3/objects/tickets/7714",
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "not found",
+			id:             "123",
+			wantPath:       "/crm/v3/objects/tickets/123",
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:         "empty id",
+			id:           " ",
+			wantErrorMsg: "ticket identifier requires a value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotRequest *http.Request
+			c.HTTPClient = &MockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					gotRequest = req
+					return newMockResponse(tt.wantStatusCode, `{
+						"id": "7714",
+						"properties": {"subject": "Sign in escalation", "hs_ticket_priority": "HIGH"}
+					}`), nil
+				},
+			}
+
+			ticket, err := c.ReadTicket(tt.id, "subject,hs_ticket_priority")
+
+			switch {
+			case tt.wantErrorMsg != "":
+				assert.EqualError(t, err, tt.wantErrorMsg)
+				assert.Nil(t, gotRequest, "expected no request to be sent")
+			case tt.wantStatusCode == http.StatusNotFound:
+				assert.True(t, errors.Is(err, hubSpot.ErrNotFound), "expected not found error")
+			default:
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantPath, gotRequest.URL.Path)
+				assert.Equal(t, "subject,hs_ticket_priority", gotRequest.URL.Query().Get("properties"))
+				assert.Equal(t, hubSpot.TicketPriorityHigh, ticket.Properties["hs_ticket_priority"])
+			}
+		})
+	}
+}
+
+func TestUpdateTicket(t *testing.T) {
+	c := hubSpot.NewClient("fake-api-key")
+
+	var gotRequest *http.Request
+	var gotBody hubSpot.TicketInput
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			gotRequest = req
+			body, _ := ioutil.ReadAll(req.Body)
+			assert.NoError(t, json.Unmarshal(body, &gotBody))
+			return newMockResponse(http.StatusOK, `{"id": "7714", "properties": {"hs_pipeline_stage": "4"}}`), nil
+		},
+	}
+
+	ticket, err := c.UpdateTicket("7714", hubSpot.NewTicketInput(nil).SetPipelineStage(hubSpot.TicketStageClosed))
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPatch, gotRequest.Method)
+	assert.Equal(t, "/crm/v3/objects/tickets/7714", gotRequest.URL.Path)
+	assert.Equal(t, "4", gotBody.Properties["hs_pipeline_stage"])
+	assert.Equal(t, hubSpot.TicketStageClosed, ticket.PipelineStage())
+
+	_, err = c.UpdateTicket("", hubSpot.NewTicketInput(nil))
+	assert.EqualError(t, err, "ticket identifier requires a value")
+}
+
+func TestArchiveTicket(t *testing.T) {
+	c := hubSpot.NewClient("fake-api-key")
+
+	var gotRequest *http.Request
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			gotRequest = req
+			return newMockResponse(http.StatusNoContent, ""), nil
+		},
+	}
+
+	err := c.ArchiveTicket("7714")
+
+	assert.NoError(t, err, "ensure the function archived the ticket")
+	assert.Equal(t, http.MethodDelete, gotRequest.Method)
+	assert.Equal(t, "/crm/v3/objects/tickets/7714", gotRequest.URL.Path)
+
+	err = c.ArchiveTicket("")
+	assert.EqualError(t, err, "ticket identifier requires a value")
+}
@@ -0,0 +1,247 @@
+package hubspot
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultPropertyCacheTTL is how long a PropertyValidator caches property definitions by default
+const DefaultPropertyCacheTTL = 10 * time.Minute
+
+// Property validation failure reasons
+const (
+	ValidationUnknownProperty = "UNKNOWN_PROPERTY"
+	ValidationReadOnly        = "READ_ONLY_VALUE"
+	ValidationInvalidOption   = "INVALID_OPTION"
+	ValidationInvalidBool     = "INVALID_BOOL"
+	ValidationInvalidNumber   = "INVALID_NUMBER"
+	ValidationInvalidDate     = "INVALID_DATE"
+)
+
+// PropertyError handles a single property value that failed validation
+type PropertyError struct {
+	Property string
+	Value    string
+	// Reason is one of the Validation constants
+	Reason  string
+	Message string
+}
+
+// ValidationError is returned when property values failed validation before being
+// sent to HubSpot, Errors lists every invalid property ordered by name
+type ValidationError struct {
+	ObjectType string
+	Errors     []PropertyError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, propertyError := range e.Errors {
+		messages[i] = fmt.Sprintf("%s: %s", propertyError.Property, propertyError.Message)
+	}
+	return fmt.Sprintf("%d invalid %s properties: %s", len(e.Errors), e.ObjectType, strings.Join(messages, "; "))
+}
+
+// PropertyValidator validates property values against the property definitions of the
+// portal before they are sent, catching what HubSpot reports as a generic VALIDATION_ERROR.
+// Definitions are loaded with the client on first use and cached per object type.
+type PropertyValidator struct {
+	// CacheTTL is how long property definitions are cached, DefaultPropertyCacheTTL when zero
+	CacheTTL time.Duration
+
+	mu    sync.Mutex
+	cache map[string]*propertyDefinitions
+	// generation is incremented by Invalidate, so definitions loaded before are not cached
+	generation int
+}
+
+// propertyDefinitions handles the cached property definitions of an object type
+type propertyDefinitions struct {
+	properties map[string]*Property
+	loadedAt   time.Time
+}
+
+// NewPropertyValidator creates a new PropertyValidator with corresponding defaults
+func NewPropertyValidator() *PropertyValidator {
+	return &PropertyValidator{
+		CacheTTL: DefaultPropertyCacheTTL,
+	}
+}
+
+// Validate checks property values of an object type, loading its property definitions with
+// the client when they are not cached, and returns a *ValidationError for invalid values
+func (v *PropertyValidator) Validate(
+	ctx context.Context,
+	client *Client,
+	objectType string,
+	properties map[string]string) error {
+
+	if len(properties) == 0 {
+		return nil
+	}
+
+	definitions, err := v.definitions(ctx, client, objectType)
+	if err != nil {
+		return fmt.Errorf("unable to load %s property definitions, err: %w", objectType, err)
+	}
+
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var propertyErrors []PropertyError
+	for _, name := range names {
+		if propertyError := validateProperty(definitions[name], name, properties[name]); propertyError != nil {
+			propertyErrors = append(propertyErrors, *propertyError)
+		}
+	}
+
+	if len(propertyErrors) > 0 {
+		return &ValidationError{ObjectType: objectType, Errors: propertyErrors}
+	}
+	return nil
+}
+
+// Invalidate drops the cached property definitions, e.g. after changing them
+func (v *PropertyValidator) Invalidate() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.cache = nil
+	v.generation++
+}
+
+// definitions returns the property definitions of an object type, loading them when the
+// cache is empty or expired. The lock is not held while loading, so concurrent callers may
+// load the same definitions and the last one loaded is cached.
+func (v *PropertyValidator) definitions(ctx context.Context, client *Client, objectType string) (map[string]*Property, error) {
+	ttl := v.CacheTTL
+	if ttl <= 0 {
+		ttl = DefaultPropertyCacheTTL
+	}
+
+	v.mu.Lock()
+	cached := v.cache[objectType]
+	generation := v.generation
+	v.mu.Unlock()
+
+	if cached != nil && time.Since(cached.loadedAt) < ttl {
+		return cached.properties, nil
+	}
+
+	properties, err := client.Properties(objectType).List(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	cached = &propertyDefinitions{
+		properties: make(map[string]*Property, len(properties)),
+		loadedAt:   time.Now(),
+	}
+	for i := range properties {
+		cached.properties[properties[i].Name] = &properties[i]
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	// definitions loaded before an Invalidate may already be outdated
+	if generation == v.generation {
+		if v.cache == nil {
+			v.cache = map[string]*propertyDefinitions{}
+		}
+		v.cache[objectType] = cached
+	}
+	return cached.properties, nil
+}
+
+// validateProperty checks a single property value against its definition, an empty value
+// clears a writable property and is always valid
+func validateProperty(property *Property, name string, value string) *PropertyError {
+	invalid := func(reason string, format string, args ...interface{}) *PropertyError {
+		return &PropertyError{Property: name, Value: value, Reason: reason, Message: fmt.Sprintf(format, args...)}
+	}
+
+	switch {
+	case property == nil:
+		return invalid(ValidationUnknownProperty, "unknown property")
+	case property.IsReadOnly():
+		return invalid(ValidationReadOnly, "property is read-only")
+	case value == "":
+		return nil
+	}
+
+	switch property.Type {
+	case PropertyTypeEnumeration:
+		if property.ExternalOptions {
+			return nil
+		}
+		values := []string{value}
+		if property.FieldType == PropertyFieldTypeCheckbox {
+			values = strings.Split(value, multiSelectSeparator)
+		}
+		for _, v := range values {
+			if !hasOption(property.Options, v) {
+				return invalid(ValidationInvalidOption, "%q is not an option", v)
+			}
+		}
+	case PropertyTypeBool:
+		// HubSpot only accepts the lower case literals, unlike strconv.ParseBool
+		if value != "true" && value != "false" {
+			return invalid(ValidationInvalidBool, "%q is not true or false", value)
+		}
+	case PropertyTypeNumber:
+		if !isDecimalNumber(value) {
+			return invalid(ValidationInvalidNumber, "%q is not a number", value)
+		}
+	case PropertyTypeDate:
+		t, err := ParseDateTime(value)
+		if err != nil {
+			return invalid(ValidationInvalidDate, "%q is not a date", value)
+		}
+		if !t.Equal(t.UTC().Truncate(24 * time.Hour)) {
+			return invalid(ValidationInvalidDate, "%q is not midnight UTC", value)
+		}
+	case PropertyTypeDateTime:
+		if _, err := ParseDateTime(value); err != nil {
+			return invalid(ValidationInvalidDate, "%q is not a datetime", value)
+		}
+	}
+	return nil
+}
+
+// isDecimalNumber reports whether value is a finite decimal number, strconv.ParseFloat
+// also accepts NaN, infinity and hex floats which HubSpot rejects
+func isDecimalNumber(value string) bool {
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+		return false
+	}
+	return !strings.ContainsAny(value, "xXpP_")
+}
+
+// hasOption reports whether value is the value of one of the options
+func hasOption(options []PropertyOption, value string) bool {
+	for _, option := range options {
+		if option.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+// validateContactInput validates a contact input with the client Validator, when set
+func (c *Client) validateContactInput(ctx context.Context, contactInput *ContactInput) error {
+	if c.Validator == nil || contactInput == nil {
+		return nil
+	}
+	return c.Validator.Validate(ctx, c, ObjectTypeContacts, contactInput.Properties)
+}
@@ -0,0 +1,187 @@
+package hubspot_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	hubSpot "github.com/teamexos/hubspot-api-go/hubspot"
+)
+
+const contactPropertiesJSON = `{"results": [
+	{"name": "email", "type": "string", "fieldType": "text"},
+	{"name": "hs_object_id", "type": "number", "fieldType": "number",
+		"modificationMetadata": {"archivable": false, "readOnlyDefinition": true, "readOnlyValue": true}},
+	{"name": "exos_perform_account_verified", "type": "enumeration", "fieldType": "booleancheckbox",
+		"options": [{"label": "Yes", "value": "true"}, {"label": "No", "value": "false"}]},
+	{"name": "exos_sports", "type": "enumeration", "fieldType": "checkbox",
+		"options": [{"label": "Football", "value": "football"}, {"label": "Tennis", "value": "tennis"}]},
+	{"name": "exos_sessions", "type": "number", "fieldType": "number"},
+	{"name": "exos_newsletter", "type": "bool", "fieldType": "booleancheckbox"},
+	{"name": "date_of_birth", "type": "date", "fieldType": "date"},
+	{"name": "exos_last_session", "type": "datetime", "fieldType": "date"}
+]}`
+
+func TestPropertyValidator(t *testing.T) {
+	c := hubSpot.NewClient("fake-api-key")
+	c.Validator = hubSpot.NewPropertyValidator()
+
+	var gotRequests []string
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			gotRequests = append(gotRequests, req.Method+" "+req.URL.Path)
+			if req.Method == http.MethodGet {
+				return newMockResponse(http.StatusOK, contactPropertiesJSON), nil
+			}
+			return newMockResponse(http.StatusCreated, `{"id": "551"}`), nil
+		},
+	}
+
+	_, err := c.CreateContact(hubSpot.NewContactInput(map[string]string{
+		"email":                         "pp@gmail.com",
+		"hs_object_id":                  "551",
+		"exos_perform_account_verified": "yes",
+		"exos_sports":                   "football;golf",
+		"exos_sessions":                 "twelve",
+		"date_of_birth":                 "2001-08-10T12:00:00.000Z",
+		"exos_last_session":             "yesterday",
+		"exos_favorite_coach":           "Sam",
+	}))
+
+	var validationErr *hubSpot.ValidationError
+	assert.True(t, errors.As(err, &validationErr), "expected a validation error")
+	assert.Equal(t, []hubSpot.PropertyError{
+		{Property: "date_of_birth", Value: "2001-08-10T12:00:00.000Z", Reason: hubSpot.ValidationInvalidDate,
+			Message: `"2001-08-10T12:00:00.000Z" is not midnight UTC`},
+		{Property: "exos_favorite_coach", Value: "Sam", Reason: hubSpot.ValidationUnknownProperty,
+			Message: "unknown property"},
+		{Property: "exos_last_session", Value: "yesterday", Reason: hubSpot.ValidationInvalidDate,
+			Message: `"yesterday" is not a datetime`},
+		{Property: "exos_perform_account_verified", Value: "yes", Reason: hubSpot.ValidationInvalidOption,
+			Message: `"yes" is not an option`},
+		{Property: "exos_sessions", Value: "twelve", Reason: hubSpot.ValidationInvalidNumber,
+			Message: `"twelve" is not a number`},
+		{Property: "exos_sports", Value: "football;golf", Reason: hubSpot.ValidationInvalidOption,
+			Message: `"golf" is not an option`},
+		{Property: "hs_object_id", Value: "551", Reason: hubSpot.ValidationReadOnly,
+			Message: "property is read-only"},
+	}, validationErr.Errors)
+	assert.Equal(t, []string{"GET /crm/v3/properties/contacts"}, gotRequests, "expected no contact to be sent")
+
+	contact, err := c.CreateContact(hubSpot.NewContactInput(map[string]string{
+		"email":                         "pp@gmail.com",
+		"exos_perform_account_verified": "true",
+		"exos_sports":                   "football;tennis",
+		"exos_sessions":                 "12",
+		"date_of_birth":                 "2001-08-10",
+		"exos_last_session":             "2021-01-12T15:47:54.554Z",
+	}))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "551", contact.ID)
+	assert.Equal(t, []string{
+		"GET /crm/v3/properties/contacts",
+		"POST /crm/v3/objects/contacts",
+	}, gotRequests, "expected the property definitions to be cached")
+}
+
+func TestPropertyValidatorLiterals(t *testing.T) {
+	c := hubSpot.NewClient("fake-api-key")
+	c.Validator = hubSpot.NewPropertyValidator()
+	c.HTTPClient = NewMockHTTPClient(http.StatusOK, contactPropertiesJSON)
+
+	tests := []struct {
+		property   string
+		value      string
+		wantReason string
+	}{
+		{property: "exos_newsletter", value: "true"},
+		{property: "exos_newsletter", value: "false"},
+		{property: "exos_newsletter", value: "TRUE", wantReason: hubSpot.ValidationInvalidBool},
+		{property: "exos_newsletter", value: "1", wantReason: hubSpot.ValidationInvalidBool},
+		{property: "exos_newsletter", value: "t", wantReason: hubSpot.ValidationInvalidBool},
+		{property: "exos_sessions", value: "-12.5"},
+		{property: "exos_sessions", value: "1e3"},
+		{property: "exos_sessions", value: "NaN", wantReason: hubSpot.ValidationInvalidNumber},
+		{property: "exos_sessions", value: "Inf", wantReason: hubSpot.ValidationInvalidNumber},
+		{property: "exos_sessions", value: "-infinity", wantReason: hubSpot.ValidationInvalidNumber},
+		{property: "exos_sessions", value: "0x1p-2", wantReason: hubSpot.ValidationInvalidNumber},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.property+"="+tt.value, func(t *testing.T) {
+			err := c.Validator.Validate(context.Background(), c, hubSpot.ObjectTypeContacts,
+				map[string]string{tt.property: tt.value})
+
+			if tt.wantReason == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var validationErr *hubSpot.ValidationError
+			if assert.True(t, errors.As(err, &validationErr), "expected a validation error") {
+				assert.Equal(t, tt.wantReason, validationErr.Errors[0].Reason)
+			}
+		})
+	}
+}
+
+func TestPropertyValidatorBatch(t *testing.T) {
+	c := hubSpot.NewClient("fake-api-key")
+	c.Validator = hubSpot.NewPropertyValidator()
+
+	var gotInputs []map[string]interface{}
+	c.HTTPClient = &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if req.Method == http.MethodGet {
+				return newMockResponse(http.StatusOK, contactPropertiesJSON), nil
+			}
+
+			var body struct {
+				Inputs []map[string]interface{} `json:"inputs"`
+			}
+			raw, _ := ioutil.ReadAll(req.Body)
+			assert.NoError(t, json.Unmarshal(raw, &body))
+			gotInputs = body.Inputs
+
+			if req.URL.Path == "/crm/v3/objects/contacts/batch/create" {
+				return newMockResponse(http.StatusCreated, `{"status": "COMPLETE", "results": [
+					{"id": "551", "properties": {"email": "pp@gmail.com"}}
+				]}`), nil
+			}
+			return newMockResponse(http.StatusOK, `{"status": "COMPLETE", "results": [
+				{"id": "552", "properties": {"exos_sessions": "3"}}
+			]}`), nil
+		},
+	}
+
+	results, err := c.BatchCreateContacts([]*hubSpot.ContactInput{
+		hubSpot.NewContactInput(map[string]string{"email": "tony@marvel.com", "exos_sessions": "twelve"}),
+		hubSpot.NewContactInput(map[string]string{"email": "pp@gmail.com", "exos_sessions": "12"}),
+	})
+
+	var batchErr *hubSpot.BatchError
+	assert.True(t, errors.As(err, &batchErr), "expected a batch error")
+	var validationErr *hubSpot.ValidationError
+	assert.True(t, errors.As(results[0].Err, &validationErr), "expected the invalid input to fail validation")
+	assert.Equal(t, "exos_sessions", validationErr.Errors[0].Property)
+	assert.NoError(t, results[1].Err)
+	assert.Equal(t, "551", results[1].Contact.ID)
+	assert.Len(t, gotInputs, 1, "expected the invalid input not to be sent")
+
+	results, err = c.BatchUpdateContacts([]hubSpot.BatchUpdateInput{
+		{ID: "551", Properties: map[string]string{"hs_object_id": "552"}},
+		{ID: "552", Properties: map[string]string{"exos_sessions": "3"}},
+	})
+
+	assert.True(t, errors.As(err, &batchErr), "expected a batch error")
+	assert.True(t, errors.As(results[0].Err, &validationErr), "expected the read-only property to fail validation")
+	assert.Equal(t, hubSpot.ValidationReadOnly, validationErr.Errors[0].Reason)
+	assert.Equal(t, "552", results[1].Contact.ID)
+	assert.Equal(t, []map[string]interface{}{
+		{"id": "552", "properties": map[string]interface{}{"exos_sessions": "3"}},
+	}, gotInputs)
+}